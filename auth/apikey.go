@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// API keys look like gtk_<prefix>_<secret>; the hex prefix identifies the key
+const (
+	apiKeyMarker      = "gtk_"
+	apiKeyPrefixBytes = 6
+	apiKeySecretBytes = 32
+)
+
+// lastUsedResolution limits how often a key's last-used timestamp is written
+const lastUsedResolution = time.Minute
+
+// API key verification errors
+var (
+	ErrAPIKeyMalformed = errors.New("API key is malformed")
+	ErrAPIKeyUnknown   = errors.New("API key is not recognised")
+	ErrAPIKeyInactive  = errors.New("API key is revoked or expired")
+)
+
+// GeneratedAPIKey is a freshly issued key. Key is shown to the caller once;
+// only Prefix and Hash are stored.
+type GeneratedAPIKey struct {
+	Key    string
+	Prefix string
+	Hash   string
+}
+
+// GenerateAPIKey creates a random API key
+func GenerateAPIKey() (*GeneratedAPIKey, error) {
+	prefix := make([]byte, apiKeyPrefixBytes)
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("generate API key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate API key: %w", err)
+	}
+
+	generated := &GeneratedAPIKey{Prefix: hex.EncodeToString(prefix)}
+	generated.Key = apiKeyMarker + generated.Prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	generated.Hash = HashAPIKey(generated.Key)
+	return generated, nil
+}
+
+// HashAPIKey returns the stored form of a key. Keys carry 256 bits of
+// entropy, so a fast hash is sufficient.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyPrefix extracts the identifying prefix from a presented key
+func APIKeyPrefix(key string) (string, error) {
+	rest, ok := strings.CutPrefix(key, apiKeyMarker)
+	if !ok {
+		return "", ErrAPIKeyMalformed
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != hex.EncodedLen(apiKeyPrefixBytes) || secret == "" {
+		return "", ErrAPIKeyMalformed
+	}
+	if _, err := hex.DecodeString(prefix); err != nil {
+		return "", ErrAPIKeyMalformed
+	}
+	return prefix, nil
+}
+
+// APIKeyStore looks up stored API keys
+type APIKeyStore interface {
+	// FindAPIKeyByPrefix returns a key with its owning user loaded
+	FindAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	// MarkAPIKeyUsed records when a key was last used
+	MarkAPIKeyUsed(ctx context.Context, id uint, at time.Time) error
+}
+
+// APIKeyVerifier authenticates callers presenting an API key
+type APIKeyVerifier struct {
+	store APIKeyStore
+	now   func() time.Time
+}
+
+// NewAPIKeyVerifier creates a verifier backed by a key store
+func NewAPIKeyVerifier(store APIKeyStore) *APIKeyVerifier {
+	return &APIKeyVerifier{store: store, now: time.Now}
+}
+
+// Verify checks a presented key and returns the owning user as principal.
+// The key's scopes are exposed through the scope claim.
+func (v *APIKeyVerifier) Verify(ctx context.Context, key string) (*Principal, error) {
+	prefix, err := APIKeyPrefix(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// A store failure is not the caller's fault, so only a missing key rejects it
+	stored, err := v.store.FindAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAPIKeyUnknown
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(stored.Hash)) != 1 {
+		return nil, ErrAPIKeyUnknown
+	}
+
+	now := v.now()
+	if !stored.Active(now) {
+		return nil, ErrAPIKeyInactive
+	}
+	// A key whose user was deleted no longer authenticates
+	if stored.User.ID == 0 || stored.User.Email == "" {
+		return nil, ErrAPIKeyInactive
+	}
+
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= lastUsedResolution {
+		// Failing to record usage must not fail the request
+		_ = v.store.MarkAPIKeyUsed(ctx, stored.ID, now)
+	}
+
+	return &Principal{
+		Subject: stored.User.Email,
+		Claims: map[string]interface{}{
+			"sub":        stored.User.Email,
+			"scope":      stored.Scopes,
+			"user_id":    stored.UserID,
+			"api_key_id": stored.ID,
+		},
+	}, nil
+}
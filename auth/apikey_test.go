@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// memoryKeyStore is an APIKeyStore holding a single key
+type memoryKeyStore struct {
+	key  *models.APIKey
+	used int
+	err  error
+}
+
+func (s *memoryKeyStore) FindAPIKeyByPrefix(_ context.Context, prefix string) (*models.APIKey, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.key == nil || s.key.Prefix != prefix {
+		return nil, gorm.ErrRecordNotFound
+	}
+	key := *s.key
+	return &key, nil
+}
+
+func (s *memoryKeyStore) MarkAPIKeyUsed(_ context.Context, _ uint, at time.Time) error {
+	s.used++
+	s.key.LastUsedAt = &at
+	return nil
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	generated, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	prefix, err := APIKeyPrefix(generated.Key)
+	require.NoError(t, err)
+	assert.Equal(t, generated.Prefix, prefix)
+	assert.Equal(t, HashAPIKey(generated.Key), generated.Hash)
+	assert.NotContains(t, generated.Hash, generated.Key)
+
+	for _, malformed := range []string{"", "gtk_", "gtk_abc_secret", "xyz_0123456789ab_secret", "gtk_0123456789ab_"} {
+		_, err := APIKeyPrefix(malformed)
+		assert.ErrorIs(t, err, ErrAPIKeyMalformed, malformed)
+	}
+}
+
+func TestAPIKeyVerifier(t *testing.T) {
+	generated, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	newVerifier := func(mutate func(*models.APIKey)) (*APIKeyVerifier, *memoryKeyStore) {
+		key := &models.APIKey{
+			UserID: 7,
+			User:   models.User{Base: models.Base{ID: 7}, Email: "svc@example.com"},
+			Prefix: generated.Prefix,
+			Hash:   generated.Hash,
+			Scopes: "employees:read employees:write",
+		}
+		key.ID = 3
+		if mutate != nil {
+			mutate(key)
+		}
+		store := &memoryKeyStore{key: key}
+		verifier := NewAPIKeyVerifier(store)
+		verifier.now = func() time.Time { return now }
+		return verifier, store
+	}
+
+	t.Run("valid key", func(t *testing.T) {
+		verifier, store := newVerifier(nil)
+		principal, err := verifier.Verify(context.Background(), generated.Key)
+		require.NoError(t, err)
+		assert.Equal(t, "svc@example.com", principal.Subject)
+		assert.Equal(t, []string{"employees:read", "employees:write"}, principal.Scopes())
+
+		// Usage is recorded at most once per resolution window
+		_, err = verifier.Verify(context.Background(), generated.Key)
+		require.NoError(t, err)
+		assert.Equal(t, 1, store.used)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		verifier, _ := newVerifier(nil)
+		_, err := verifier.Verify(context.Background(), "gtk_"+generated.Prefix+"_forged")
+		assert.ErrorIs(t, err, ErrAPIKeyUnknown)
+	})
+
+	t.Run("unknown prefix", func(t *testing.T) {
+		verifier, _ := newVerifier(func(k *models.APIKey) { k.Prefix = "000000000000" })
+		_, err := verifier.Verify(context.Background(), generated.Key)
+		assert.ErrorIs(t, err, ErrAPIKeyUnknown)
+	})
+
+	t.Run("failing store", func(t *testing.T) {
+		verifier, store := newVerifier(nil)
+		store.err = assert.AnError
+		_, err := verifier.Verify(context.Background(), generated.Key)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NotErrorIs(t, err, ErrAPIKeyUnknown)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		verifier, _ := newVerifier(func(k *models.APIKey) { k.RevokedAt = &now })
+		_, err := verifier.Verify(context.Background(), generated.Key)
+		assert.ErrorIs(t, err, ErrAPIKeyInactive)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := now.Add(-time.Second)
+		verifier, _ := newVerifier(func(k *models.APIKey) { k.ExpiresAt = &expired })
+		_, err := verifier.Verify(context.Background(), generated.Key)
+		assert.ErrorIs(t, err, ErrAPIKeyInactive)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		verifier, _ := newVerifier(func(k *models.APIKey) { k.User = models.User{} })
+		_, err := verifier.Verify(context.Background(), generated.Key)
+		assert.ErrorIs(t, err, ErrAPIKeyInactive)
+	})
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Token verification errors
+var (
+	ErrTokenExpired      = errors.New("token has expired")
+	ErrTokenNotYet       = errors.New("token is not valid yet")
+	ErrTokenAudience     = errors.New("token audience is not accepted")
+	ErrTokenIssuer       = errors.New("token issuer is not accepted")
+	ErrTokenInvalid      = errors.New("token is invalid")
+	ErrNoVerificationKey = errors.New("no JWT verification key configured")
+)
+
+// Principal is the authenticated caller
+type Principal struct {
+	// Subject is the token's sub claim
+	Subject string
+	// Claims holds every claim of the verified token
+	Claims jwt.MapClaims
+}
+
+// Roles returns the roles claim, given as a list or a single string
+func (p *Principal) Roles() []string {
+	return stringsClaim(p.Claims["roles"])
+}
+
+// Scopes returns the OAuth scopes from the space separated scope claim or the scp list
+func (p *Principal) Scopes() []string {
+	if scope, ok := p.Claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	return stringsClaim(p.Claims["scp"])
+}
+
+// stringsClaim reads a claim holding a string or a list of strings
+func stringsClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// VerifierConfig holds the keys and expectations used to verify tokens
+type VerifierConfig struct {
+	// Secret verifies HS256 tokens
+	Secret []byte
+	// PublicKey verifies RS256 tokens without a kid header
+	PublicKey *rsa.PublicKey
+	// JWKS verifies RS256 tokens by kid
+	JWKS map[string]*rsa.PublicKey
+	// Issuer and Audience are enforced when set
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew on exp, nbf and iat
+	Leeway time.Duration
+}
+
+// Verifier validates HS256 and RS256 bearer tokens
+type Verifier struct {
+	secret  []byte
+	rsaKeys map[string]*rsa.PublicKey
+	parser  *jwt.Parser
+}
+
+// NewVerifier creates a verifier accepting the algorithms its keys allow
+func NewVerifier(cfg VerifierConfig) (*Verifier, error) {
+	v := &Verifier{secret: cfg.Secret, rsaKeys: map[string]*rsa.PublicKey{}}
+	for kid, key := range cfg.JWKS {
+		v.rsaKeys[kid] = key
+	}
+	if cfg.PublicKey != nil {
+		v.rsaKeys[""] = cfg.PublicKey
+	}
+
+	var methods []string
+	if len(v.secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(v.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, ErrNoVerificationKey
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(options...)
+	return v, nil
+}
+
+// Verify checks a token's signature and claims and returns its principal.
+// Verification is local, so ctx is unused.
+func (v *Verifier) Verify(_ context.Context, tokenString string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(tokenString, claims, v.key); err != nil {
+		return nil, classify(err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrTokenInvalid)
+	}
+	return &Principal{Subject: subject, Claims: claims}, nil
+}
+
+// key selects the verification key for a token
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// A kid-less token can only be matched against a single known key
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// classify maps parser errors onto this package's errors
+func classify(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ErrTokenNotYet
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrTokenAudience
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrTokenIssuer
+	default:
+		return fmt.Errorf("%w: %v", ErrTokenInvalid, err)
+	}
+}
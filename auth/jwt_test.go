@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// sign creates a token with the given claims, signing method and key
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// validClaims returns claims accepted by a verifier expecting the test issuer and audience
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub": "user-42",
+		"iss": "https://issuer.example.com",
+		"aud": "employee-api",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	verifier, err := NewVerifier(VerifierConfig{
+		Secret:   []byte(testSecret),
+		Issuer:   "https://issuer.example.com",
+		Audience: "employee-api",
+	})
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		principal, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "user-42", principal.Subject)
+		assert.Equal(t, "employee-api", principal.Claims["aud"])
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		key    []byte
+		want   error
+	}{
+		{name: "expired", mutate: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, want: ErrTokenExpired},
+		{name: "missing expiry", mutate: func(c jwt.MapClaims) { delete(c, "exp") }, want: ErrTokenInvalid},
+		{name: "not yet valid", mutate: func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(time.Hour).Unix() }, want: ErrTokenNotYet},
+		{name: "wrong audience", mutate: func(c jwt.MapClaims) { c["aud"] = "other-api" }, want: ErrTokenAudience},
+		{name: "wrong issuer", mutate: func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, want: ErrTokenIssuer},
+		{name: "missing subject", mutate: func(c jwt.MapClaims) { delete(c, "sub") }, want: ErrTokenInvalid},
+		{name: "wrong secret", key: []byte("fedcba9876543210fedcba9876543210"), want: ErrTokenInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			if tt.mutate != nil {
+				tt.mutate(claims)
+			}
+			key := tt.key
+			if key == nil {
+				key = []byte(testSecret)
+			}
+
+			_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, key, "", claims))
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), "not-a-token")
+		assert.ErrorIs(t, err, ErrTokenInvalid)
+	})
+}
+
+func TestVerifier_RS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwksDocument := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"key-1","use":"sig","alg":"RS256","n":%q,"e":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+	keys, err := ParseJWKS([]byte(jwksDocument))
+	require.NoError(t, err)
+
+	verifier, err := NewVerifier(VerifierConfig{JWKS: keys})
+	require.NoError(t, err)
+
+	principal, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, key, "key-1", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", principal.Subject)
+
+	_, err = verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, key, "key-2", validClaims()))
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+
+	// HS256 is not accepted when only RSA keys are configured
+	_, err = verifier.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()))
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+}
+
+func TestNewVerifier_RequiresKey(t *testing.T) {
+	_, err := NewVerifier(VerifierConfig{})
+	assert.ErrorIs(t, err, ErrNoVerificationKey)
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwks is the JSON Web Key Set document format (RFC 7517)
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwk is a single JSON Web Key; only RSA signing keys are used
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// LoadJWKSFile reads RSA verification keys from a local JWKS file, keyed by kid
+func LoadJWKSFile(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read JWKS file: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+// ParseJWKS parses RSA verification keys from a JWKS document, keyed by kid.
+// Keys of other types or meant for encryption are skipped.
+func ParseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parse JWKS: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for i, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		if _, exists := keys[key.Kid]; exists {
+			return nil, fmt.Errorf("parse JWKS: duplicate key id %q", key.Kid)
+		}
+
+		publicKey, err := rsaPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("parse JWKS: key %d (%q): %w", i, key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("parse JWKS: no RSA signing keys found")
+	}
+	return keys, nil
+}
+
+// rsaPublicKey decodes the base64url modulus and exponent of an RSA JWK
+func rsaPublicKey(key jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil || len(n) == 0 {
+		return nil, fmt.Errorf("invalid modulus")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+
+	exponent := 0
+	for _, b := range e {
+		exponent = exponent<<8 | int(b)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}, nil
+}
+
+// LoadRSAPublicKeyFile reads a PEM encoded RSA public key or certificate
+func LoadRSAPublicKeyFile(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read public key file: %w", err)
+	}
+
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key file: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Permission names a single operation a caller may perform
+type Permission string
+
+// Employee permissions
+const (
+	PermEmployeesRead          Permission = "employees:read"
+	PermEmployeesReadSensitive Permission = "employees:read_sensitive"
+	PermEmployeesWrite         Permission = "employees:write"
+	PermEmployeesDelete        Permission = "employees:delete"
+	PermEmployeesPurge         Permission = "employees:purge"
+)
+
+// Department permissions
+const (
+	PermDepartmentsRead  Permission = "departments:read"
+	PermDepartmentsWrite Permission = "departments:write"
+)
+
+// User and post permissions
+const (
+	PermUsersRead  Permission = "users:read"
+	PermUsersWrite Permission = "users:write"
+	PermPostsRead  Permission = "posts:read"
+	PermPostsWrite Permission = "posts:write"
+)
+
+// PermAPIKeysManage allows issuing, rotating and revoking any user's API keys
+const PermAPIKeysManage Permission = "api_keys:manage"
+
+// Roles known to the default policy
+const (
+	RoleViewer = "viewer"
+	RoleHR     = "hr"
+	RoleAdmin  = "admin"
+)
+
+// ErrNoRule is returned for routes the policy does not mention; they are denied
+var ErrNoRule = errors.New("no access rule for route")
+
+// PermissionError reports the permission a caller is missing
+type PermissionError struct {
+	Permission Permission
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("missing permission %s", e.Permission)
+}
+
+// Rule requires a permission for a method and route pattern, e.g. GET /employees/:id
+type Rule struct {
+	Method     string
+	Route      string
+	Permission Permission
+}
+
+// Policy maps roles to permissions and routes and response fields to the
+// permissions they require
+type Policy struct {
+	// Roles lists the permissions each role grants
+	Roles map[string][]Permission
+	// Rules lists the permission each route requires
+	Rules []Rule
+	// Fields lists response fields visible only with a permission, by JSON name
+	Fields map[string]Permission
+}
+
+// DefaultPolicy returns the built-in access policy: viewers read, HR also
+// writes and sees compensation fields, admins do everything including
+// deleting employees and managing API keys
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Roles: map[string][]Permission{
+			RoleViewer: {PermEmployeesRead, PermDepartmentsRead, PermUsersRead, PermPostsRead},
+			RoleHR: {
+				PermEmployeesRead, PermEmployeesReadSensitive, PermEmployeesWrite,
+				PermDepartmentsRead, PermDepartmentsWrite,
+				PermUsersRead, PermUsersWrite, PermPostsRead, PermPostsWrite,
+			},
+			RoleAdmin: {
+				PermEmployeesRead, PermEmployeesReadSensitive, PermEmployeesWrite,
+				PermEmployeesDelete, PermEmployeesPurge,
+				PermDepartmentsRead, PermDepartmentsWrite,
+				PermUsersRead, PermUsersWrite, PermPostsRead, PermPostsWrite,
+				PermAPIKeysManage,
+			},
+		},
+		Rules: []Rule{
+			{Method: "GET", Route: "/employees", Permission: PermEmployeesRead},
+			{Method: "GET", Route: "/employees/export", Permission: PermEmployeesRead},
+			{Method: "GET", Route: "/employees/search", Permission: PermEmployeesRead},
+			{Method: "GET", Route: "/employees/:id", Permission: PermEmployeesRead},
+			{Method: "POST", Route: "/employees", Permission: PermEmployeesWrite},
+			{Method: "POST", Route: "/employees:action", Permission: PermEmployeesWrite},
+			{Method: "POST", Route: "/employees/import", Permission: PermEmployeesWrite},
+			{Method: "PUT", Route: "/employees/:id", Permission: PermEmployeesWrite},
+			{Method: "PATCH", Route: "/employees/:id", Permission: PermEmployeesWrite},
+			{Method: "DELETE", Route: "/employees/:id", Permission: PermEmployeesDelete},
+			{Method: "POST", Route: "/employees/:id/restore", Permission: PermEmployeesDelete},
+			{Method: "DELETE", Route: "/employees/:id/purge", Permission: PermEmployeesPurge},
+			{Method: "GET", Route: "/departments", Permission: PermDepartmentsRead},
+			{Method: "GET", Route: "/departments/:id", Permission: PermDepartmentsRead},
+			{Method: "GET", Route: "/departments/:id/employees", Permission: PermEmployeesRead},
+			{Method: "POST", Route: "/departments", Permission: PermDepartmentsWrite},
+			{Method: "PUT", Route: "/departments/:id", Permission: PermDepartmentsWrite},
+			{Method: "DELETE", Route: "/departments/:id", Permission: PermDepartmentsWrite},
+			{Method: "GET", Route: "/users", Permission: PermUsersRead},
+			{Method: "GET", Route: "/users/:id", Permission: PermUsersRead},
+			{Method: "POST", Route: "/users", Permission: PermUsersWrite},
+			{Method: "PUT", Route: "/users/:id", Permission: PermUsersWrite},
+			{Method: "DELETE", Route: "/users/:id", Permission: PermUsersWrite},
+			{Method: "GET", Route: "/posts", Permission: PermPostsRead},
+			{Method: "GET", Route: "/posts/:id", Permission: PermPostsRead},
+			{Method: "GET", Route: "/users/:id/posts", Permission: PermPostsRead},
+			{Method: "POST", Route: "/posts", Permission: PermPostsWrite},
+			{Method: "POST", Route: "/users/:id/posts", Permission: PermPostsWrite},
+			{Method: "PUT", Route: "/posts/:id", Permission: PermPostsWrite},
+			{Method: "DELETE", Route: "/posts/:id", Permission: PermPostsWrite},
+			{Method: "GET", Route: "/users/:id/api-keys", Permission: PermAPIKeysManage},
+			{Method: "POST", Route: "/users/:id/api-keys", Permission: PermAPIKeysManage},
+			{Method: "POST", Route: "/users/:id/api-keys/:key_id/rotate", Permission: PermAPIKeysManage},
+			{Method: "DELETE", Route: "/users/:id/api-keys/:key_id", Permission: PermAPIKeysManage},
+		},
+		Fields: map[string]Permission{
+			"salary": PermEmployeesReadSensitive,
+		},
+	}
+}
+
+// PermissionSet is the set of permissions granted to a caller
+type PermissionSet map[Permission]bool
+
+// Has reports whether the set contains a permission
+func (s PermissionSet) Has(permission Permission) bool {
+	return s[permission]
+}
+
+// Grants returns the permissions granted by a caller's roles and scopes.
+// Unknown roles grant nothing; a scope grants the permission of the same name.
+func (p *Policy) Grants(roles, scopes []string) PermissionSet {
+	granted := PermissionSet{}
+	for _, role := range roles {
+		for _, permission := range p.Roles[role] {
+			granted[permission] = true
+		}
+	}
+	for _, scope := range scopes {
+		granted[Permission(scope)] = true
+	}
+	return granted
+}
+
+// Authorize checks whether granted permissions allow a method on a route.
+// It returns a *PermissionError naming the missing permission, or ErrNoRule.
+func (p *Policy) Authorize(method, route string, granted PermissionSet) error {
+	for _, rule := range p.Rules {
+		if strings.EqualFold(rule.Method, method) && rule.Route == route {
+			if !granted.Has(rule.Permission) {
+				return &PermissionError{Permission: rule.Permission}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %s %s", ErrNoRule, method, route)
+}
+
+// HiddenFields returns the response fields the granted permissions may not see, sorted
+func (p *Policy) HiddenFields(granted PermissionSet) []string {
+	var hidden []string
+	for field, permission := range p.Fields {
+		if !granted.Has(permission) {
+			hidden = append(hidden, field)
+		}
+	}
+	sort.Strings(hidden)
+	return hidden
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	policy := DefaultPolicy()
+
+	tests := []struct {
+		name    string
+		roles   []string
+		scopes  []string
+		method  string
+		route   string
+		missing Permission
+	}{
+		{name: "viewer reads", roles: []string{RoleViewer}, method: "GET", route: "/employees/:id"},
+		{name: "viewer exports", roles: []string{RoleViewer}, method: "GET", route: "/employees/export"},
+		{name: "viewer searches", roles: []string{RoleViewer}, method: "GET", route: "/employees/search"},
+		{name: "viewer cannot create", roles: []string{RoleViewer}, method: "POST", route: "/employees", missing: PermEmployeesWrite},
+		{name: "hr updates", roles: []string{RoleHR}, method: "PUT", route: "/employees/:id"},
+		{name: "hr runs batches", roles: []string{RoleHR}, method: "POST", route: "/employees:action"},
+		{name: "hr imports", roles: []string{RoleHR}, method: "POST", route: "/employees/import"},
+		{name: "viewer cannot import", roles: []string{RoleViewer}, method: "POST", route: "/employees/import", missing: PermEmployeesWrite},
+		{name: "hr cannot delete", roles: []string{RoleHR}, method: "DELETE", route: "/employees/:id", missing: PermEmployeesDelete},
+		{name: "viewer lists department employees", roles: []string{RoleViewer}, method: "GET", route: "/departments/:id/employees"},
+		{name: "viewer cannot delete departments", roles: []string{RoleViewer}, method: "DELETE", route: "/departments/:id", missing: PermDepartmentsWrite},
+		{name: "hr deletes departments", roles: []string{RoleHR}, method: "DELETE", route: "/departments/:id"},
+		{name: "admin purges", roles: []string{RoleAdmin}, method: "DELETE", route: "/employees/:id/purge"},
+		{name: "scope grants permission", scopes: []string{"employees:delete"}, method: "POST", route: "/employees/:id/restore"},
+		{name: "unknown role", roles: []string{"intern"}, method: "GET", route: "/employees", missing: PermEmployeesRead},
+		{name: "method is case insensitive", roles: []string{RoleViewer}, method: "get", route: "/employees"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.method, tt.route, policy.Grants(tt.roles, tt.scopes))
+			if tt.missing == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var permissionErr *PermissionError
+			if assert.ErrorAs(t, err, &permissionErr) {
+				assert.Equal(t, tt.missing, permissionErr.Permission)
+			}
+		})
+	}
+
+	t.Run("unmapped route is denied", func(t *testing.T) {
+		err := policy.Authorize("GET", "/payroll", policy.Grants([]string{RoleAdmin}, nil))
+		assert.ErrorIs(t, err, ErrNoRule)
+	})
+}
+
+func TestPolicy_HiddenFields(t *testing.T) {
+	policy := DefaultPolicy()
+
+	assert.Equal(t, []string{"salary"}, policy.HiddenFields(policy.Grants([]string{RoleViewer}, nil)))
+	assert.Empty(t, policy.HiddenFields(policy.Grants([]string{RoleHR}, nil)))
+}
+
+func TestPrincipal_RolesAndScopes(t *testing.T) {
+	principal := &Principal{Claims: map[string]interface{}{
+		"roles": []interface{}{"hr", "viewer"},
+		"scope": "employees:read employees:write",
+	}}
+	assert.Equal(t, []string{"hr", "viewer"}, principal.Roles())
+	assert.Equal(t, []string{"employees:read", "employees:write"}, principal.Scopes())
+
+	principal = &Principal{Claims: map[string]interface{}{
+		"roles": "admin",
+		"scp":   []interface{}{"employees:purge"},
+	}}
+	assert.Equal(t, []string{"admin"}, principal.Roles())
+	assert.Equal(t, []string{"employees:purge"}, principal.Scopes())
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/yourname/employee-api/config"
+)
+
+// ConfigCommand validates and prints the effective configuration
+type ConfigCommand struct {
+	Config config.Options
+}
+
+// NewConfigCommand creates a new config command instance
+func NewConfigCommand() *ConfigCommand {
+	return &ConfigCommand{}
+}
+
+// Name returns the command name
+func (c *ConfigCommand) Name() string {
+	return "config"
+}
+
+// Synopsis returns the command description
+func (c *ConfigCommand) Synopsis() string {
+	return "Validate and print the effective configuration with secrets redacted"
+}
+
+// Run executes the config command
+func (c *ConfigCommand) Run(args []string) error {
+	flags := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	c.Config.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(c.Config)
+	if err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE\tENV")
+	for _, setting := range cfg.Effective() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", setting.Key, orDash(setting.Value), setting.Source, setting.Env)
+	}
+	return w.Flush()
+}
+
+// orDash renders empty values visibly in tables
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
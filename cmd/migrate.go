@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/config"
+	"github.com/yourname/employee-api/migrations"
+	"github.com/yourname/employee-api/migrator"
+	"github.com/yourname/employee-api/utils"
+)
+
+// MigrateCommand applies the SQL migrations embedded in the binary
+type MigrateCommand struct {
+	// Path optionally reads migrations from a directory instead
+	Path string
+	// Config selects the configuration file and overrides
+	Config config.Options
+}
+
+// NewMigrateCommand creates a new migrate command instance
+func NewMigrateCommand() *MigrateCommand {
+	return &MigrateCommand{}
+}
+
+// Name returns the command name
+func (m *MigrateCommand) Name() string {
+	return "migrate"
+}
+
+// Synopsis returns the command description
+func (m *MigrateCommand) Synopsis() string {
+	return "Apply or roll back database migrations (up, down, status, goto)"
+}
+
+// Run executes the migrate command
+func (m *MigrateCommand) Run(args []string) error {
+	flags := flag.NewFlagSet(m.Name(), flag.ContinueOnError)
+	flags.StringVar(&m.Path, "path", m.Path, "read *.up.sql and *.down.sql files from this directory instead of the embedded set")
+	m.Config.RegisterFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s migrate [-path dir] [-config file] [-set key=value] <action>\n\n", filepath.Base(os.Args[0]))
+		fmt.Fprintln(flags.Output(), "Actions:")
+		fmt.Fprintln(flags.Output(), "  up [N]      apply all or the next N pending migrations")
+		fmt.Fprintln(flags.Output(), "  down [N]    roll back the last N migrations (default 1)")
+		fmt.Fprintln(flags.Output(), "  status      list migrations and whether they are applied")
+		fmt.Fprintln(flags.Output(), "  goto V      migrate up or down to version V")
+		fmt.Fprintln(flags.Output(), "\nFlags:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("missing action")
+	}
+
+	action, rest := flags.Arg(0), flags.Args()[1:]
+
+	logger := utils.InitLogger()
+
+	cfg, err := config.Load(m.Config)
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+
+	// Initialize database connection
+	if err := config.InitDB(cfg); err != nil {
+		return fmt.Errorf("initialize database connection: %w", err)
+	}
+	defer config.CloseDB() //nolint:errcheck // best effort on exit
+
+	sqlDB, err := config.GetDB().DB()
+	if err != nil {
+		return err
+	}
+
+	var source fs.FS = migrations.FS
+	if m.Path != "" {
+		source = os.DirFS(m.Path)
+	}
+
+	engine, err := migrator.New(sqlDB, source)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch action {
+	case "up":
+		n, err := optionalCount(rest, 0)
+		if err != nil {
+			return err
+		}
+		applied, err := engine.Up(ctx, n)
+		logger.WithField("applied", applied).Info("Migrate up finished")
+		return err
+
+	case "down":
+		n, err := optionalCount(rest, 1)
+		if err != nil {
+			return err
+		}
+		reverted, err := engine.Down(ctx, n)
+		logger.WithField("reverted", reverted).Info("Migrate down finished")
+		return err
+
+	case "goto":
+		if len(rest) != 1 {
+			return fmt.Errorf("goto requires exactly one version")
+		}
+		version, err := strconv.ParseUint(rest[0], 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", rest[0])
+		}
+		if err := engine.Goto(ctx, uint(version)); err != nil {
+			return err
+		}
+		logger.WithFields(logrus.Fields{"version": version}).Info("Migrate goto finished")
+		return nil
+
+	case "status":
+		return printMigrationStatus(ctx, engine)
+
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown action %q", action)
+	}
+}
+
+// optionalCount parses an optional positive count argument
+func optionalCount(args []string, fallback int) (int, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid count %q", args[0])
+	}
+	return n, nil
+}
+
+// printMigrationStatus prints a table of known migrations
+func printMigrationStatus(ctx context.Context, engine *migrator.Migrator) error {
+	statuses, err := engine.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.Modified {
+			state = "modified"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
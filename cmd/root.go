@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Command is a single CLI subcommand
+type Command interface {
+	// Name is the word used to invoke the command
+	Name() string
+	// Synopsis is a one-line description shown in the usage text
+	Synopsis() string
+	// Run executes the command with the arguments following its name
+	Run(args []string) error
+}
+
+// defaultCommand runs when the binary is started without a subcommand
+const defaultCommand = "serve"
+
+// commands returns every registered subcommand keyed by name
+func commands() map[string]Command {
+	registered := []Command{
+		NewServerCommand(),
+		NewMigrateCommand(),
+		NewSeedCommand(),
+		NewConfigCommand(),
+		NewVersionCommand(),
+	}
+
+	byName := make(map[string]Command, len(registered))
+	for _, command := range registered {
+		byName[command.Name()] = command
+	}
+	return byName
+}
+
+// Execute runs the subcommand named by args and returns the process exit code
+func Execute(args []string) int {
+	available := commands()
+
+	// Flags without a command belong to the default command
+	name := defaultCommand
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" {
+		printUsage(os.Stdout, available)
+		return 0
+	}
+
+	command, ok := available[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage(os.Stderr, available)
+		return 2
+	}
+
+	if err := command.Run(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+// printUsage lists the available subcommands
+func printUsage(w io.Writer, available map[string]Command) {
+	names := make([]string, 0, len(available))
+	for name := range available {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "Usage: %s <command> [flags]\n\n", filepath.Base(os.Args[0]))
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, available[name].Synopsis())
+	}
+	fmt.Fprintf(w, "\nRunning without a command is the same as %q.\n", defaultCommand)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/yourname/employee-api/config"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
+)
+
+// Name pools used to generate fake employees
+var (
+	seedFirstNames = []string{
+		"Ada", "Alan", "Barbara", "Claude", "Dennis", "Donald", "Edsger", "Frances",
+		"Grace", "Hedy", "John", "Katherine", "Ken", "Linus", "Margaret", "Radia",
+	}
+	seedLastNames = []string{
+		"Allen", "Dijkstra", "Hamilton", "Hopper", "Johnson", "Kernighan", "Knuth", "Lamarr",
+		"Liskov", "Lovelace", "McCarthy", "Perlman", "Ritchie", "Shannon", "Thompson", "Turing",
+	}
+)
+
+// SeedCommand inserts fake employees for local development
+type SeedCommand struct {
+	Count  int
+	Seed   int64
+	Config config.Options
+}
+
+// NewSeedCommand creates a new seed command instance
+func NewSeedCommand() *SeedCommand {
+	return &SeedCommand{
+		Count: 25,
+	}
+}
+
+// Name returns the command name
+func (s *SeedCommand) Name() string {
+	return "seed"
+}
+
+// Synopsis returns the command description
+func (s *SeedCommand) Synopsis() string {
+	return "Insert fake employees for local development"
+}
+
+// Run executes the seed command
+func (s *SeedCommand) Run(args []string) error {
+	flags := flag.NewFlagSet(s.Name(), flag.ContinueOnError)
+	flags.IntVar(&s.Count, "count", s.Count, "number of employees to create")
+	flags.Int64Var(&s.Seed, "seed", s.Seed, "random seed for reproducible data (0 picks one from the clock)")
+	s.Config.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if s.Count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+
+	logger := utils.InitLogger()
+
+	cfg, err := config.Load(s.Config)
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+
+	// Initialize database connection
+	if err := config.InitDB(cfg); err != nil {
+		return fmt.Errorf("initialize database connection: %w", err)
+	}
+	defer config.CloseDB() //nolint:errcheck // best effort on exit
+
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed)) //nolint:gosec // fake data, not security sensitive
+
+	repo := repository.NewGormEmployeeRepository(config.GetDB())
+	ctx := context.Background()
+
+	for i := 0; i < s.Count; i++ {
+		employee := models.Employee{
+			FirstName: seedFirstNames[rng.Intn(len(seedFirstNames))],
+			LastName:  seedLastNames[rng.Intn(len(seedLastNames))],
+		}
+		if err := repo.Create(ctx, &employee); err != nil {
+			return fmt.Errorf("create employee %d of %d: %w", i+1, s.Count, err)
+		}
+	}
+
+	logger.WithField("count", s.Count).WithField("seed", seed).Info("Seeded employees")
+	return nil
+}
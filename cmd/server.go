@@ -1,13 +1,38 @@
 package cmd
 
 import (
-	"log"
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/auth"
+	"github.com/yourname/employee-api/config"
+	"github.com/yourname/employee-api/handlers"
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
 )
 
+// shutdownTimeout bounds how long outstanding requests may take on shutdown
+const shutdownTimeout = 30 * time.Second
+
+// idempotencySweepInterval is how often expired Idempotency-Key records are deleted
+const idempotencySweepInterval = 10 * time.Minute
+
 // ServerCommand represents the server command
 type ServerCommand struct {
-	Port int
-	Host string
+	Port   int
+	Host   string
+	Config config.Options
 }
 
 // NewServerCommand creates a new server command instance
@@ -18,9 +43,293 @@ func NewServerCommand() *ServerCommand {
 	}
 }
 
+// Name returns the command name
+func (s *ServerCommand) Name() string {
+	return "serve"
+}
+
+// Synopsis returns the command description
+func (s *ServerCommand) Synopsis() string {
+	return "Start the HTTP API server"
+}
+
 // Run executes the server command
-func (s *ServerCommand) Run() error {
-	log.Printf("Starting server on %s:%d", s.Host, s.Port)
-	// TODO: Implement server startup logic
+func (s *ServerCommand) Run(args []string) error {
+	// Initialize centralized logger
+	logger := utils.InitLogger()
+	logger.Info("GormTest application starting...")
+
+	// -host and -port are shorthands for the matching -set overrides
+	flags := flag.NewFlagSet(s.Name(), flag.ContinueOnError)
+	flags.StringVar(&s.Host, "host", s.Host, "address to listen on (overrides server.host)")
+	flags.IntVar(&s.Port, "port", s.Port, "port to listen on (overrides server.port)")
+	s.Config.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "host":
+			s.Config.Set("server.host", s.Host)
+		case "port":
+			s.Config.Set("server.port", strconv.Itoa(s.Port))
+		}
+	})
+
+	// Load configuration: defaults < config file < environment < flags
+	cfg, err := config.Load(s.Config)
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+	s.Host = cfg.Server.Host
+	if port, err := strconv.Atoi(cfg.Server.Port); err == nil {
+		s.Port = port
+	}
+
+	logger.WithFields(logrus.Fields{
+		"server_port": s.Port,
+		"server_host": s.Host,
+	}).Info("Configuration loaded")
+
+	// Initialize database connection
+	if err := config.InitDB(cfg); err != nil {
+		return fmt.Errorf("initialize database connection: %w", err)
+	}
+	logger.Info("Database connection initialized successfully")
+
+	// JWT verification keys are loaded once; API keys are looked up per request
+	apiKeys := repository.NewGormAPIKeyRepository(config.GetDB())
+	access, err := accessMiddleware(cfg, apiKeys, logger)
+	if err != nil {
+		_ = config.CloseDB()
+		return fmt.Errorf("configure authentication: %w", err)
+	}
+
+	// Runtime-safe settings can change on SIGHUP or when the config file is edited
+	reloader := config.NewReloader(s.Config, cfg, logger)
+	reloader.OnChange(func(cfg *config.Config) { applyRuntimeConfig(cfg, logger) })
+	applyRuntimeConfig(cfg, logger)
+
+	// Create Gin router with centralized middleware
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(logger))
+	router.Use(middleware.CORS(func() []string {
+		return reloader.Current().Server.CORSOrigins
+	}))
+	router.Use(middleware.RateLimit(func() middleware.RateLimitConfig {
+		server := reloader.Current().Server
+		return middleware.RateLimitConfig{PerSecond: server.RateLimit, Burst: server.RateLimitBurst}
+	}, logger))
+	router.Use(middleware.ErrorHandler(logger))
+
+	// Register routes
+	idempotencyKeys := repository.NewGormIdempotencyRepository(config.GetDB())
+	registerRoutes(router, cfg, access, apiKeys, idempotencyKeys, logger)
+	logger.Info("Routes registered successfully")
+
+	// Configure server
+	serverAddr := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	server := &http.Server{
+		Addr:              serverAddr,
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Start server in a goroutine
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.WithField("address", serverAddr).Info("Starting HTTP server")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+	}()
+
+	// Reload when the config file changes
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go func() {
+		if err := reloader.Watch(watchCtx); err != nil {
+			logger.WithError(err).Warn("Configuration file watching disabled")
+		}
+	}()
+	go sweepIdempotencyKeys(watchCtx, idempotencyKeys, logger)
+
+	// Reload on SIGHUP; wait for interrupt signal to gracefully shutdown the server
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+wait:
+	for {
+		select {
+		case <-hangup:
+			logger.Info("Received SIGHUP, reloading configuration")
+			_ = reloader.Reload() // failures are logged and keep the running configuration
+		case <-quit:
+			break wait
+		case err := <-serverErr:
+			_ = config.CloseDB()
+			return fmt.Errorf("start server: %w", err)
+		}
+	}
+	stopWatching()
+	logger.Info("Shutting down server...")
+
+	// Give outstanding requests a deadline for completion
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// Shutdown server
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("server forced to shutdown: %w", err)
+	}
+
+	// Close database connection
+	if err := config.CloseDB(); err != nil {
+		logger.WithError(err).Error("Error closing database connection")
+	}
+
+	logger.Info("Server exited gracefully")
 	return nil
 }
+
+// applyRuntimeConfig pushes reloadable settings into components that cache them
+func applyRuntimeConfig(cfg *config.Config, logger *logrus.Logger) {
+	if level, err := logrus.ParseLevel(cfg.App.LogLevel); err == nil {
+		logger.SetLevel(level)
+	}
+	config.SetSQLLogging(cfg.Database)
+}
+
+// sweepIdempotencyKeys deletes expired Idempotency-Key records until ctx is done
+func sweepIdempotencyKeys(ctx context.Context, repo repository.IdempotencyRepository, logger *logrus.Logger) {
+	ticker := time.NewTicker(idempotencySweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := repo.DeleteExpired(ctx, now)
+			if err != nil {
+				logger.WithError(err).Warn("Failed to delete expired idempotency keys")
+				continue
+			}
+			if deleted > 0 {
+				logger.WithField("deleted", deleted).Debug("Deleted expired idempotency keys")
+			}
+		}
+	}
+}
+
+// accessMiddleware builds the authentication and authorization middleware from
+// the auth settings. Callers present either a JWT bearer token or an API key.
+// Without any JWT verification key, development servers run without access
+// control and other environments refuse to start.
+func accessMiddleware(cfg *config.Config, apiKeys auth.APIKeyStore, logger *logrus.Logger) ([]gin.HandlerFunc, error) {
+	if !cfg.Auth.HasVerificationKey() {
+		if !cfg.App.IsDevelopment() {
+			return nil, fmt.Errorf("%w (APP_ENV=%s): set JWT_SECRET, JWT_PUBLIC_KEY_FILE or JWT_JWKS_FILE",
+				auth.ErrNoVerificationKey, cfg.App.Environment)
+		}
+		logger.Warn("No JWT verification key configured, employee routes are unauthenticated")
+		return nil, nil
+	}
+
+	verifierConfig := auth.VerifierConfig{
+		Secret:   []byte(cfg.Auth.JWTSecret),
+		Issuer:   cfg.Auth.Issuer,
+		Audience: cfg.Auth.Audience,
+		Leeway:   cfg.Auth.Leeway,
+	}
+	if cfg.Auth.JWTPublicKeyFile != "" {
+		key, err := auth.LoadRSAPublicKeyFile(cfg.Auth.JWTPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		verifierConfig.PublicKey = key
+	}
+	if cfg.Auth.JWKSFile != "" {
+		keys, err := auth.LoadJWKSFile(cfg.Auth.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		verifierConfig.JWKS = keys
+	}
+
+	verifier, err := auth.NewVerifier(verifierConfig)
+	if err != nil {
+		return nil, err
+	}
+	return []gin.HandlerFunc{
+		middleware.AuthenticateSchemes(map[string]middleware.TokenVerifier{
+			middleware.SchemeBearer: verifier,
+			middleware.SchemeAPIKey: auth.NewAPIKeyVerifier(apiKeys),
+		}, logger),
+		middleware.Authorize(auth.DefaultPolicy(), logger),
+	}, nil
+}
+
+// registerRoutes registers all application routes
+func registerRoutes(router *gin.Engine, cfg *config.Config, access []gin.HandlerFunc, apiKeyRepo repository.APIKeyRepository,
+	idempotencyKeys repository.IdempotencyRepository, logger *logrus.Logger) {
+	// Health check route
+	router.GET("/health", handlers.HealthCheckHandler)
+
+	// Unknown paths get the same error format as everything else
+	router.NoRoute(handlers.NotFound)
+
+	// Employee routes require credentials whose roles or scopes allow the route
+	employeeRepo := repository.NewGormEmployeeRepository(config.GetDB())
+	employees := handlers.NewEmployeeHandler(employeeRepo)
+	authenticated := router.Group("/", access...)
+	authenticated.GET("/employees", employees.List)
+	authenticated.GET("/employees/export", employees.Export)
+	authenticated.GET("/employees/search", employees.Search)
+	// Integration jobs retry creates; an Idempotency-Key keeps retries from duplicating employees
+	idempotent := middleware.Idempotency(idempotencyKeys, cfg.Server.IdempotencyTTL, logger)
+	authenticated.POST("/employees", idempotent, employees.Create)
+	authenticated.POST("/employees:action", idempotent, employees.Batch)
+	authenticated.POST("/employees/import", employees.Import)
+	authenticated.GET("/employees/:id", employees.Get)
+	authenticated.PUT("/employees/:id", employees.Update)
+	authenticated.PATCH("/employees/:id", employees.Patch)
+	authenticated.DELETE("/employees/:id", employees.Delete)
+	authenticated.POST("/employees/:id/restore", employees.Restore)
+	authenticated.DELETE("/employees/:id/purge", employees.Purge)
+
+	// Department routes
+	departments := handlers.NewDepartmentHandler(repository.NewGormDepartmentRepository(config.GetDB()), employeeRepo)
+	authenticated.GET("/departments", departments.List)
+	authenticated.POST("/departments", departments.Create)
+	authenticated.GET("/departments/:id", departments.Get)
+	authenticated.PUT("/departments/:id", departments.Update)
+	authenticated.DELETE("/departments/:id", departments.Delete)
+	authenticated.GET("/departments/:id/employees", departments.Employees)
+
+	// User and post routes
+	users := handlers.NewUserHandler(repository.NewGormUserRepository(config.GetDB()))
+	authenticated.GET("/users", users.List)
+	authenticated.POST("/users", users.Create)
+	authenticated.GET("/users/:id", users.Get)
+	authenticated.PUT("/users/:id", users.Update)
+	authenticated.DELETE("/users/:id", users.Delete)
+
+	posts := handlers.NewPostHandler(repository.NewGormPostRepository(config.GetDB()))
+	authenticated.GET("/posts", posts.List)
+	authenticated.POST("/posts", posts.Create)
+	authenticated.GET("/posts/:id", posts.Get)
+	authenticated.PUT("/posts/:id", posts.Update)
+	authenticated.DELETE("/posts/:id", posts.Delete)
+	authenticated.GET("/users/:id/posts", posts.List)
+	authenticated.POST("/users/:id/posts", posts.Create)
+
+	// API key management routes
+	apiKeys := handlers.NewAPIKeyHandler(apiKeyRepo)
+	authenticated.GET("/users/:id/api-keys", apiKeys.List)
+	authenticated.POST("/users/:id/api-keys", apiKeys.Create)
+	authenticated.POST("/users/:id/api-keys/:key_id/rotate", apiKeys.Rotate)
+	authenticated.DELETE("/users/:id/api-keys/:key_id", apiKeys.Revoke)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Build metadata, set at link time:
+//
+//	go build -ldflags "-X github.com/yourname/employee-api/cmd.Version=v1.2.3"
+var (
+	Version   = "dev"
+	Commit    = "none"
+	BuildDate = "unknown"
+)
+
+// VersionCommand prints build metadata
+type VersionCommand struct{}
+
+// NewVersionCommand creates a new version command instance
+func NewVersionCommand() *VersionCommand {
+	return &VersionCommand{}
+}
+
+// Name returns the command name
+func (v *VersionCommand) Name() string {
+	return "version"
+}
+
+// Synopsis returns the command description
+func (v *VersionCommand) Synopsis() string {
+	return "Print build version information"
+}
+
+// Run executes the version command
+func (v *VersionCommand) Run(_ []string) error {
+	fmt.Printf("employee-api %s\n", Version)
+	fmt.Printf("  commit:     %s\n", Commit)
+	fmt.Printf("  built:      %s\n", BuildDate)
+	fmt.Printf("  go version: %s\n", runtime.Version())
+	fmt.Printf("  platform:   %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	return nil
+}
@@ -1,53 +1,173 @@
 package config
 
 import (
-	"os"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// EnvDevelopment is the environment name that relaxes production safety checks
+const EnvDevelopment = "development"
+
+// defaultPasswords are well-known passwords that must not be used outside
+// development. An empty password is not one of them: it leaves authentication
+// to the server, e.g. trust, peer or certificate authentication.
+var defaultPasswords = map[string]bool{
+	"1234":     true,
+	"admin":    true,
+	"changeme": true,
+	"password": true,
+	"postgres": true,
+}
+
 // Config holds application configuration
 type Config struct {
+	App      AppConfig
 	Database DatabaseConfig
 	Server   ServerConfig
+	Auth     AuthConfig
+
+	// sources records which layer supplied each setting
+	sources map[string]Source
+}
+
+// AppConfig holds application-wide settings
+type AppConfig struct {
+	Environment string
+	LogLevel    string
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	Username string
-	Password string
-	Database string
-	SSLMode  string
+	// URL is a full connection string that overrides the individual fields below
+	URL             string
+	Host            string
+	Port            string
+	Username        string
+	Password        string
+	Database        string
+	SSLMode         string
+	SSLRootCert     string
+	SSLCert         string
+	SSLKey          string
+	ApplicationName string
+	SearchPath      string
+	AutoMigrate     bool
+
+	// Connection pool settings; zero durations mean no limit
+	MaxIdleConns    int
+	MaxOpenConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// PrepareStmt caches prepared statements per connection
+	PrepareStmt bool
+	// QueryTimeout bounds every statement whose context has no deadline; zero disables it
+	QueryTimeout time.Duration
+
+	// SQL logging settings
+	LogLevel      string
+	SlowThreshold time.Duration
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	Port string
 	Host string
+
+	// RateLimit is the sustained requests per second allowed per client; zero disables limiting
+	RateLimit      int
+	RateLimitBurst int
+	// CORSOrigins lists origins allowed to make cross-origin requests; "*" allows any
+	CORSOrigins []string
+	// IdempotencyTTL is how long responses to requests with an Idempotency-Key are replayed
+	IdempotencyTTL time.Duration
+}
+
+// IsDevelopment reports whether the application runs in development mode
+func (a AppConfig) IsDevelopment() bool {
+	return a.Environment == EnvDevelopment
+}
+
+// AuthConfig holds bearer token verification settings
+type AuthConfig struct {
+	// JWTSecret verifies HS256 tokens
+	JWTSecret string
+	// JWTPublicKeyFile is a PEM RSA public key verifying RS256 tokens
+	JWTPublicKeyFile string
+	// JWKSFile is a local JSON Web Key Set verifying RS256 tokens by kid
+	JWKSFile string
+	// Issuer and Audience are enforced when set
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew when checking token lifetimes
+	Leeway time.Duration
 }
 
-// Load loads configuration from environment variables
-func Load() *Config {
-	return &Config{
-		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			Username: getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", ""),
-			Database: getEnv("DB_NAME", "gormtest"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
-		},
-		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "localhost"),
-			Port: getEnv("SERVER_PORT", "8080"),
-		},
+// HasVerificationKey reports whether any JWT verification key is configured
+func (a AuthConfig) HasVerificationKey() bool {
+	return a.JWTSecret != "" || a.JWTPublicKeyFile != "" || a.JWKSFile != ""
+}
+
+// DSN returns the connection string, preferring URL when it is set
+func (d DatabaseConfig) DSN() string {
+	if d.URL != "" {
+		return d.URL
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", d.SSLMode)
+	setIfNotEmpty(query, "sslrootcert", d.SSLRootCert)
+	setIfNotEmpty(query, "sslcert", d.SSLCert)
+	setIfNotEmpty(query, "sslkey", d.SSLKey)
+	setIfNotEmpty(query, "application_name", d.ApplicationName)
+	setIfNotEmpty(query, "search_path", d.SearchPath)
+
+	user := url.User(d.Username)
+	if d.Password != "" {
+		user = url.UserPassword(d.Username, d.Password)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     user,
+		Host:     net.JoinHostPort(d.Host, d.Port),
+		Path:     "/" + d.Database,
+		RawQuery: query.Encode(),
 	}
+	return dsn.String()
+}
+
+// RedactedDSN returns the connection string with the password masked, for logging
+func (d DatabaseConfig) RedactedDSN() string {
+	dsn, err := url.Parse(d.DSN())
+	if err != nil {
+		// Keyword/value connection strings are not URLs; never log them raw
+		return "<unparseable connection string>"
+	}
+	return dsn.Redacted()
+}
+
+// EffectivePassword returns the password the connection will use, including
+// one embedded in URL or supplied through PGPASSWORD
+func (d DatabaseConfig) EffectivePassword() string {
+	parsed, err := pgconn.ParseConfig(d.DSN())
+	if err != nil {
+		return d.Password
+	}
+	return parsed.Password
+}
+
+// UsesDefaultPassword reports whether the connection uses a well-known password
+func (d DatabaseConfig) UsesDefaultPassword() bool {
+	return defaultPasswords[d.EffectivePassword()]
 }
 
-// getEnv gets environment variable with fallback
-func getEnv(key, fallback string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// setIfNotEmpty sets a query parameter only when the value is present
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
 	}
-	return fallback
 }
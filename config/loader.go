@@ -0,0 +1,380 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the environment variable that points at a config file
+const ConfigFileEnv = "CONFIG_FILE"
+
+// Source identifies the layer a setting's value came from
+type Source string
+
+// Configuration layers, from lowest to highest precedence
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// redactedValue replaces secrets when printing the configuration
+const redactedValue = "******"
+
+// setting describes one configuration value and every way it can be set
+type setting struct {
+	key    string // dotted key used in config files and -set flags
+	env    string
+	def    string
+	secret bool
+	// reloadable settings may change at runtime; others need a restart
+	reloadable bool
+	field      func(*Config) interface{} // pointer to the target field
+}
+
+// settings lists every configurable value in display order
+var settings = []setting{
+	{key: "app.env", env: "APP_ENV", def: EnvDevelopment, field: func(c *Config) interface{} { return &c.App.Environment }},
+	{key: "app.log_level", env: "LOG_LEVEL", def: "info", reloadable: true, field: func(c *Config) interface{} { return &c.App.LogLevel }},
+
+	{key: "database.url", env: "DATABASE_URL", secret: true, field: func(c *Config) interface{} { return &c.Database.URL }},
+	{key: "database.host", env: "DB_HOST", def: "localhost", field: func(c *Config) interface{} { return &c.Database.Host }},
+	{key: "database.port", env: "DB_PORT", def: "5432", field: func(c *Config) interface{} { return &c.Database.Port }},
+	{key: "database.user", env: "DB_USER", def: "postgres", field: func(c *Config) interface{} { return &c.Database.Username }},
+	{key: "database.password", env: "DB_PASSWORD", secret: true, field: func(c *Config) interface{} { return &c.Database.Password }},
+	{key: "database.name", env: "DB_NAME", def: "gormtest", field: func(c *Config) interface{} { return &c.Database.Database }},
+	{key: "database.sslmode", env: "DB_SSLMODE", def: "disable", field: func(c *Config) interface{} { return &c.Database.SSLMode }},
+	{key: "database.sslrootcert", env: "DB_SSLROOTCERT", field: func(c *Config) interface{} { return &c.Database.SSLRootCert }},
+	{key: "database.sslcert", env: "DB_SSLCERT", field: func(c *Config) interface{} { return &c.Database.SSLCert }},
+	{key: "database.sslkey", env: "DB_SSLKEY", field: func(c *Config) interface{} { return &c.Database.SSLKey }},
+	{key: "database.application_name", env: "DB_APPLICATION_NAME", def: "employee-api", field: func(c *Config) interface{} { return &c.Database.ApplicationName }},
+	{key: "database.search_path", env: "DB_SEARCH_PATH", field: func(c *Config) interface{} { return &c.Database.SearchPath }},
+	{key: "database.auto_migrate", env: "DB_AUTO_MIGRATE", def: "false", field: func(c *Config) interface{} { return &c.Database.AutoMigrate }},
+	{key: "database.max_idle_conns", env: "DB_MAX_IDLE_CONNS", def: "10", field: func(c *Config) interface{} { return &c.Database.MaxIdleConns }},
+	{key: "database.max_open_conns", env: "DB_MAX_OPEN_CONNS", def: "100", field: func(c *Config) interface{} { return &c.Database.MaxOpenConns }},
+	{key: "database.conn_max_lifetime", env: "DB_CONN_MAX_LIFETIME", def: "1h", field: func(c *Config) interface{} { return &c.Database.ConnMaxLifetime }},
+	{key: "database.conn_max_idle_time", env: "DB_CONN_MAX_IDLE_TIME", def: "0s", field: func(c *Config) interface{} { return &c.Database.ConnMaxIdleTime }},
+	{key: "database.prepare_stmt", env: "DB_PREPARE_STMT", def: "false", field: func(c *Config) interface{} { return &c.Database.PrepareStmt }},
+	{key: "database.query_timeout", env: "DB_QUERY_TIMEOUT", def: "0s", field: func(c *Config) interface{} { return &c.Database.QueryTimeout }},
+	{key: "database.log_level", env: "DB_LOG_LEVEL", def: "info", reloadable: true, field: func(c *Config) interface{} { return &c.Database.LogLevel }},
+	{key: "database.slow_threshold", env: "DB_SLOW_THRESHOLD", def: "1s", reloadable: true, field: func(c *Config) interface{} { return &c.Database.SlowThreshold }},
+
+	{key: "server.host", env: "SERVER_HOST", def: "localhost", field: func(c *Config) interface{} { return &c.Server.Host }},
+	{key: "server.port", env: "SERVER_PORT", def: "8080", field: func(c *Config) interface{} { return &c.Server.Port }},
+	{key: "server.rate_limit", env: "RATE_LIMIT", def: "0", reloadable: true, field: func(c *Config) interface{} { return &c.Server.RateLimit }},
+	{key: "server.rate_limit_burst", env: "RATE_LIMIT_BURST", def: "20", reloadable: true, field: func(c *Config) interface{} { return &c.Server.RateLimitBurst }},
+	{key: "server.cors_origins", env: "CORS_ORIGINS", reloadable: true, field: func(c *Config) interface{} { return &c.Server.CORSOrigins }},
+	{key: "server.idempotency_ttl", env: "IDEMPOTENCY_TTL", def: "24h", field: func(c *Config) interface{} { return &c.Server.IdempotencyTTL }},
+
+	{key: "auth.jwt_secret", env: "JWT_SECRET", secret: true, field: func(c *Config) interface{} { return &c.Auth.JWTSecret }},
+	{key: "auth.jwt_public_key_file", env: "JWT_PUBLIC_KEY_FILE", field: func(c *Config) interface{} { return &c.Auth.JWTPublicKeyFile }},
+	{key: "auth.jwks_file", env: "JWT_JWKS_FILE", field: func(c *Config) interface{} { return &c.Auth.JWKSFile }},
+	{key: "auth.issuer", env: "JWT_ISSUER", field: func(c *Config) interface{} { return &c.Auth.Issuer }},
+	{key: "auth.audience", env: "JWT_AUDIENCE", field: func(c *Config) interface{} { return &c.Auth.Audience }},
+	{key: "auth.leeway", env: "JWT_LEEWAY", def: "30s", field: func(c *Config) interface{} { return &c.Auth.Leeway }},
+}
+
+// Options controls where Load reads configuration from
+type Options struct {
+	// File is a YAML or TOML config file; CONFIG_FILE is used when empty
+	File string
+	// Overrides holds key=value pairs from -set flags and take precedence over everything else
+	Overrides map[string]string
+	// LookupEnv reads environment variables; os.LookupEnv is used when nil
+	LookupEnv func(key string) (string, bool)
+}
+
+// RegisterFlags adds the -config and -set flags to a command's flag set
+func (o *Options) RegisterFlags(flags *flag.FlagSet) {
+	flags.StringVar(&o.File, "config", o.File, "YAML or TOML config file (default $"+ConfigFileEnv+")")
+	flags.Func("set", "override a setting, e.g. -set server.port=9090 (repeatable)", func(value string) error {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok {
+			return fmt.Errorf("expected key=value, got %q", value)
+		}
+		o.Set(strings.TrimSpace(key), val)
+		return nil
+	})
+}
+
+// Set records a flag-level override for a setting
+func (o *Options) Set(key, value string) {
+	if o.Overrides == nil {
+		o.Overrides = map[string]string{}
+	}
+	o.Overrides[key] = value
+}
+
+// path returns the config file to read, if any
+func (o Options) path() string {
+	if o.File != "" {
+		return o.File
+	}
+	lookupEnv := o.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	path, _ := lookupEnv(ConfigFileEnv)
+	return path
+}
+
+// layeredValue is a raw setting value and the layer that supplied it
+type layeredValue struct {
+	raw    string
+	source Source
+	origin string // env variable name or file path, for error messages
+}
+
+// Load builds the configuration from defaults, a config file, environment
+// variables and flag overrides, in increasing order of precedence, and
+// validates the result. All problems are reported together.
+func Load(opts Options) (*Config, error) {
+	lookupEnv := opts.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+
+	known := make(map[string]setting, len(settings))
+	values := make(map[string]layeredValue, len(settings))
+	for _, s := range settings {
+		known[s.key] = s
+		values[s.key] = layeredValue{raw: s.def, source: SourceDefault}
+	}
+
+	var errs []error
+
+	// Config file
+	if path := opts.path(); path != "" {
+		fileValues, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for key, raw := range fileValues {
+			if _, ok := known[key]; !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown setting in %s", key, path))
+				continue
+			}
+			values[key] = layeredValue{raw: raw, source: SourceFile, origin: path}
+		}
+	}
+
+	// Environment variables; empty values are treated as unset
+	for _, s := range settings {
+		if raw, ok := lookupEnv(s.env); ok && raw != "" {
+			values[s.key] = layeredValue{raw: raw, source: SourceEnv, origin: s.env}
+		}
+	}
+
+	// Flag overrides
+	for key, raw := range opts.Overrides {
+		if _, ok := known[key]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown setting in -set", key))
+			continue
+		}
+		values[key] = layeredValue{raw: raw, source: SourceFlag, origin: "-set " + key}
+	}
+
+	cfg := &Config{sources: make(map[string]Source, len(settings))}
+	for _, s := range settings {
+		value := values[s.key]
+		if err := assign(s.field(cfg), value.raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w%s", s.key, err, describeOrigin(value)))
+			continue
+		}
+		cfg.sources[s.key] = value.source
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(sortErrors(errs)...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// EffectiveSetting is one resolved setting for display
+type EffectiveSetting struct {
+	Key    string
+	Env    string
+	Value  string
+	Source Source
+}
+
+// Effective lists every setting with its resolved value and origin. Secrets
+// are redacted so the result is safe to print or log.
+func (c *Config) Effective() []EffectiveSetting {
+	effective := make([]EffectiveSetting, 0, len(settings))
+	for _, s := range settings {
+		value := format(s.field(c))
+		if s.secret && value != "" {
+			value = redact(s.key, value)
+		}
+
+		source := c.sources[s.key]
+		if source == "" {
+			source = SourceDefault
+		}
+
+		effective = append(effective, EffectiveSetting{Key: s.key, Env: s.env, Value: value, Source: source})
+	}
+	return effective
+}
+
+// redact masks a secret, keeping the non-secret parts of connection URLs
+func redact(key, value string) string {
+	if key == "database.url" {
+		if parsed, err := url.Parse(value); err == nil && parsed.Scheme != "" {
+			return parsed.Redacted()
+		}
+	}
+	return redactedValue
+}
+
+// readConfigFile reads a YAML or TOML file into flattened dotted keys
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	tree := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q (use .yaml, .yml or .toml)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	if err := flatten("", tree, values); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// flatten converts nested maps into dotted keys with string values
+func flatten(prefix string, tree map[string]interface{}, out map[string]string) error {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if err := flatten(key, v, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				switch item.(type) {
+				case map[string]interface{}, []interface{}:
+					return fmt.Errorf("%s: lists may only contain plain values", key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// assign parses a raw value into the field's type
+func assign(field interface{}, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch target := field.(type) {
+	case *string:
+		*target = raw
+	case *bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		*target = value
+	case *int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		*target = value
+	case *time.Duration:
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use a value such as 30s or 5m)", raw)
+		}
+		*target = value
+	case *[]string:
+		// Lists are comma separated in env vars and flags
+		var values []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		*target = values
+	default:
+		return fmt.Errorf("unsupported setting type %T", field)
+	}
+	return nil
+}
+
+// format renders a field for display
+func format(field interface{}) string {
+	switch value := field.(type) {
+	case *string:
+		return *value
+	case *bool:
+		return strconv.FormatBool(*value)
+	case *int:
+		return strconv.Itoa(*value)
+	case *time.Duration:
+		return value.String()
+	case *[]string:
+		return strings.Join(*value, ",")
+	default:
+		return fmt.Sprint(field)
+	}
+}
+
+// describeOrigin explains where a bad value came from
+func describeOrigin(value layeredValue) string {
+	switch value.source {
+	case SourceEnv:
+		return " (from environment variable " + value.origin + ")"
+	case SourceFile:
+		return " (from " + value.origin + ")"
+	case SourceFlag:
+		return " (from flag " + value.origin + ")"
+	default:
+		return ""
+	}
+}
+
+// sortErrors orders errors by message so reports are stable
+func sortErrors(errs []error) []error {
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errs
+}
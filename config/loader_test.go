@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envMap returns a LookupEnv function backed by a map
+func envMap(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+// writeConfigFile writes a config file into a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(Options{LookupEnv: envMap(nil)})
+	require.NoError(t, err)
+
+	assert.Equal(t, EnvDevelopment, cfg.App.Environment)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+	assert.Equal(t, 10, cfg.Database.MaxIdleConns)
+	assert.Equal(t, 100, cfg.Database.MaxOpenConns)
+	assert.Equal(t, time.Hour, cfg.Database.ConnMaxLifetime)
+	assert.Equal(t, time.Second, cfg.Database.SlowThreshold)
+	assert.Equal(t, "8080", cfg.Server.Port)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+database:
+  host: file-host
+  name: file-db
+  max_open_conns: 20
+  query_timeout: 30s
+server:
+  port: 7000
+`)
+
+	opts := Options{
+		File:      path,
+		LookupEnv: envMap(map[string]string{"DB_NAME": "env-db", "SERVER_PORT": "7500", "DB_HOST": ""}),
+	}
+	opts.Set("server.port", "9090")
+
+	cfg, err := Load(opts)
+	require.NoError(t, err)
+
+	// Assertions: file beats defaults, env beats file, flags beat env
+	assert.Equal(t, "file-host", cfg.Database.Host, "empty env values are ignored")
+	assert.Equal(t, "env-db", cfg.Database.Database)
+	assert.Equal(t, 20, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 30*time.Second, cfg.Database.QueryTimeout)
+	assert.Equal(t, "9090", cfg.Server.Port)
+
+	sources := map[string]Source{}
+	for _, setting := range cfg.Effective() {
+		sources[setting.Key] = setting.Source
+	}
+	assert.Equal(t, SourceFile, sources["database.host"])
+	assert.Equal(t, SourceEnv, sources["database.name"])
+	assert.Equal(t, SourceFlag, sources["server.port"])
+	assert.Equal(t, SourceDefault, sources["database.user"])
+}
+
+func TestLoad_TOMLFileFromEnv(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", `
+[database]
+prepare_stmt = true
+log_level = "warn"
+`)
+
+	cfg, err := Load(Options{LookupEnv: envMap(map[string]string{ConfigFileEnv: path})})
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Database.PrepareStmt)
+	assert.Equal(t, "warn", cfg.Database.LogLevel)
+}
+
+func TestLoad_AggregatesErrors(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "database:\n  hots: typo\n")
+
+	_, err := Load(Options{
+		File: path,
+		LookupEnv: envMap(map[string]string{
+			"SERVER_PORT":       "80a",
+			"DB_MAX_OPEN_CONNS": "lots",
+			"DB_QUERY_TIMEOUT":  "30",
+		}),
+	})
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "database.hots: unknown setting in "+path)
+	assert.Contains(t, err.Error(), `database.max_open_conns: invalid integer "lots" (from environment variable DB_MAX_OPEN_CONNS)`)
+	assert.Contains(t, err.Error(), `database.query_timeout: invalid duration "30"`)
+}
+
+func TestLoad_RejectsUnknownOverride(t *testing.T) {
+	opts := Options{LookupEnv: envMap(nil)}
+	opts.Set("server.prot", "9090")
+
+	_, err := Load(opts)
+	assert.EqualError(t, err, "server.prot: unknown setting in -set")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg, err := Load(Options{LookupEnv: envMap(nil)})
+	require.NoError(t, err)
+
+	cfg.Server.Port = "80a"
+	cfg.Database.Port = "70000"
+	cfg.Database.SSLMode = "on"
+	cfg.Database.Database = ""
+	cfg.Database.MaxIdleConns = 200
+	cfg.Database.LogLevel = "debug"
+
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, `database.log_level: must be one of silent, error, warn, info, got "debug"
+database.max_idle_conns: must not exceed database.max_open_conns (100)
+database.name: is required
+database.port: must be an integer between 1 and 65535, got "70000"
+database.sslmode: must be one of disable, allow, prefer, require, verify-ca, verify-full, got "on"
+server.port: must be an integer between 1 and 65535, got "80a"`, err.Error())
+}
+
+func TestConfig_EffectiveRedactsSecrets(t *testing.T) {
+	cfg, err := Load(Options{LookupEnv: envMap(map[string]string{
+		"DATABASE_URL": "postgres://svc:s3cret@db:5432/hr",
+		"JWT_SECRET":   "a-jwt-secret-of-at-least-32-bytes",
+	})})
+	require.NoError(t, err)
+
+	values := map[string]string{}
+	for _, setting := range cfg.Effective() {
+		values[setting.Key] = setting.Value
+	}
+	assert.Equal(t, "postgres://svc:xxxxx@db:5432/hr", values["database.url"])
+	assert.Equal(t, "******", values["auth.jwt_secret"])
+	assert.Equal(t, "", values["database.password"])
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// cancelKey stores the timeout's cancel function on the statement
+const cancelKey = "query_timeout:cancel"
+
+// QueryTimeoutPlugin applies a default timeout to statements whose context
+// has no deadline of its own. Row and Rows queries are left alone because
+// their results are read after the callback chain has finished.
+type QueryTimeoutPlugin struct {
+	timeout time.Duration
+}
+
+// NewQueryTimeoutPlugin creates a plugin enforcing the given default timeout
+func NewQueryTimeoutPlugin(timeout time.Duration) *QueryTimeoutPlugin {
+	return &QueryTimeoutPlugin{timeout: timeout}
+}
+
+// Name implements gorm.Plugin
+func (p *QueryTimeoutPlugin) Name() string {
+	return "query_timeout"
+}
+
+// Initialize implements gorm.Plugin by wrapping the create, query, update,
+// delete and raw callback chains
+func (p *QueryTimeoutPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("*").Register("query_timeout:before_create", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("*").Register("query_timeout:after_create", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("*").Register("query_timeout:before_query", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("*").Register("query_timeout:after_query", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("*").Register("query_timeout:before_update", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("*").Register("query_timeout:after_update", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("*").Register("query_timeout:before_delete", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("*").Register("query_timeout:after_delete", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("*").Register("query_timeout:before_raw", p.before); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("*").Register("query_timeout:after_raw", p.after)
+}
+
+// before swaps in a context with the default timeout
+func (p *QueryTimeoutPlugin) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	db.Statement.Context = ctx
+	db.InstanceSet(cancelKey, cancel)
+}
+
+// after releases the timeout's resources
+func (p *QueryTimeoutPlugin) after(db *gorm.DB) {
+	if cancel, ok := db.InstanceGet(cancelKey); ok {
+		if fn, ok := cancel.(context.CancelFunc); ok {
+			fn()
+		}
+	}
+}
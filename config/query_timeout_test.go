@@ -0,0 +1,58 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// dryRunDB opens a GORM handle that never contacts the server
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "postgres://localhost/unused"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestQueryTimeoutPlugin(t *testing.T) {
+	db := dryRunDB(t)
+	require.NoError(t, db.Use(NewQueryTimeoutPlugin(time.Minute)))
+
+	// Capture the context the statement executes with
+	var captured context.Context
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		captured = tx.Statement.Context
+	}))
+
+	t.Run("applies default deadline", func(t *testing.T) {
+		var employees []models.Employee
+		require.NoError(t, db.Find(&employees).Error)
+
+		deadline, ok := captured.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+		assert.ErrorIs(t, captured.Err(), context.Canceled, "context should be released after the query")
+	})
+
+	t.Run("keeps caller deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		var employees []models.Employee
+		require.NoError(t, db.WithContext(ctx).Find(&employees).Error)
+
+		deadline, _ := captured.Deadline()
+		expected, _ := ctx.Deadline()
+		assert.Equal(t, expected, deadline)
+		assert.NoError(t, captured.Err())
+	})
+}
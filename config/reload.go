@@ -0,0 +1,164 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// reloadDebounce coalesces the bursts of events editors produce when saving
+const reloadDebounce = 250 * time.Millisecond
+
+// Reloader holds the active configuration and swaps in runtime-safe changes.
+// Readers call Current on every use, so a reload takes effect immediately.
+type Reloader struct {
+	opts     Options
+	logger   *logrus.Logger
+	current  atomic.Pointer[Config]
+	mu       sync.Mutex // serializes reloads and hook registration
+	onChange []func(*Config)
+}
+
+// NewReloader creates a reloader starting from an already loaded configuration
+func NewReloader(opts Options, cfg *Config, logger *logrus.Logger) *Reloader {
+	r := &Reloader{opts: opts, logger: logger}
+	r.current.Store(cfg)
+	return r
+}
+
+// Current returns the active configuration; callers must not modify it
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// OnChange registers a hook that runs after each successful reload
+func (r *Reloader) OnChange(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onChange = append(r.onChange, fn)
+}
+
+// Reload reads the configuration again and applies the runtime-safe subset.
+// An invalid configuration is rejected and the active one stays in place;
+// changes to settings that need a restart are logged and ignored.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded, err := Load(r.opts)
+	if err != nil {
+		r.logger.WithError(err).Error("Configuration reload rejected")
+		return err
+	}
+
+	current := r.Current()
+	next := current.clone()
+	applied := logrus.Fields{}
+	var ignored []string
+	for _, s := range settings {
+		before, after := format(s.field(current)), format(s.field(loaded))
+		if before == after {
+			continue
+		}
+		if !s.reloadable {
+			ignored = append(ignored, s.key)
+			continue
+		}
+		if err := assign(s.field(next), after); err != nil {
+			r.logger.WithError(err).Error("Configuration reload rejected")
+			return fmt.Errorf("%s: %w", s.key, err)
+		}
+		next.sources[s.key] = loaded.sources[s.key]
+		applied[s.key] = fmt.Sprintf("%s -> %s", display(s, before), display(s, after))
+	}
+
+	if len(ignored) > 0 {
+		r.logger.WithField("settings", ignored).Warn("Configuration changes require a restart and were not applied")
+	}
+	if len(applied) == 0 {
+		r.logger.Info("Configuration reloaded, no runtime changes")
+		return nil
+	}
+
+	r.current.Store(next)
+	for _, fn := range r.onChange {
+		fn(next)
+	}
+	r.logger.WithFields(applied).Info("Configuration reloaded")
+	return nil
+}
+
+// Watch reloads the configuration whenever the config file changes, until ctx
+// is cancelled. It returns immediately when no config file is in use.
+func (r *Reloader) Watch(ctx context.Context) error {
+	path := r.opts.path()
+	if path == "" {
+		return nil
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watch config file: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directory so atomic saves that replace the file are seen
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("watch config file: %w", err)
+	}
+	r.logger.WithField("path", path).Info("Watching configuration file for changes")
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == path && event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			r.logger.WithError(err).Warn("Configuration file watcher error")
+		case <-debounce:
+			debounce = nil
+			_ = r.Reload() // failures are logged and leave the active config in place
+		}
+	}
+}
+
+// clone returns a copy that can be modified without affecting readers of c
+func (c *Config) clone() *Config {
+	next := *c
+	next.sources = make(map[string]Source, len(c.sources))
+	for key, source := range c.sources {
+		next.sources[key] = source
+	}
+	return &next
+}
+
+// display renders a setting value for change logs, hiding secrets
+func display(s setting, value string) string {
+	if s.secret && value != "" {
+		return redact(s.key, value)
+	}
+	if value == "" {
+		return `""`
+	}
+	return value
+}
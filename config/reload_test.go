@@ -0,0 +1,92 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestReloader loads path and returns a reloader logging into a buffer
+func newTestReloader(t *testing.T, path string) (*Reloader, *bytes.Buffer) {
+	opts := Options{File: path, LookupEnv: envMap(nil)}
+	cfg, err := Load(opts)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetOutput(buf)
+	return NewReloader(opts, cfg, logger), buf
+}
+
+func TestReloader_AppliesRuntimeSettings(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "server:\n  port: 8080\n")
+	reloader, logs := newTestReloader(t, path)
+	before := reloader.Current()
+
+	var notified *Config
+	reloader.OnChange(func(cfg *Config) { notified = cfg })
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+app:
+  log_level: debug
+database:
+  slow_threshold: 250ms
+server:
+  port: 9090
+  rate_limit: 5
+  cors_origins:
+    - https://app.example.com
+`), 0o600))
+	require.NoError(t, reloader.Reload())
+
+	current := reloader.Current()
+	assert.Same(t, current, notified)
+	assert.Equal(t, "debug", current.App.LogLevel)
+	assert.Equal(t, 250*time.Millisecond, current.Database.SlowThreshold)
+	assert.Equal(t, 5, current.Server.RateLimit)
+	assert.Equal(t, []string{"https://app.example.com"}, current.Server.CORSOrigins)
+
+	// Restart-only settings are left alone
+	assert.Equal(t, "8080", current.Server.Port)
+	assert.Contains(t, logs.String(), "require a restart")
+
+	// The previous snapshot is never mutated
+	assert.Equal(t, "info", before.App.LogLevel)
+	assert.Empty(t, before.Server.CORSOrigins)
+}
+
+func TestReloader_RejectsInvalidConfig(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "app:\n  log_level: warn\n")
+	reloader, logs := newTestReloader(t, path)
+	before := reloader.Current()
+
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  log_level: loud\nserver:\n  rate_limit: -1\n"), 0o600))
+	err := reloader.Reload()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "app.log_level")
+	assert.Contains(t, err.Error(), "server.rate_limit")
+	assert.Same(t, before, reloader.Current())
+	assert.Contains(t, logs.String(), "Configuration reload rejected")
+}
+
+func TestReloader_WatchesFile(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "app:\n  log_level: info\n")
+	reloader, _ := newTestReloader(t, path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx) //nolint:errcheck // stopped by cancel
+
+	// Keep rewriting until the watcher is registered and picks the change up
+	assert.Eventually(t, func() bool {
+		_ = os.WriteFile(path, []byte("app:\n  log_level: debug\n"), 0o600)
+		return reloader.Current().App.LogLevel == "debug"
+	}, 5*time.Second, 2*reloadDebounce)
+}
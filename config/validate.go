@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+// sslModes lists the sslmode values libpq understands
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// minJWTSecretLength is the shortest accepted HS256 secret (RFC 7518 section 3.2)
+const minJWTSecretLength = 32
+
+// logLevels lists the accepted database log levels
+var logLevels = []string{"silent", "error", "warn", "warning", "info"}
+
+// Validate checks every setting and reports all problems at once
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(key, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if strings.TrimSpace(c.App.Environment) == "" {
+		fail("app.env", "is required")
+	}
+	if _, err := logrus.ParseLevel(c.App.LogLevel); err != nil {
+		fail("app.log_level", "must be one of panic, fatal, error, warn, info, debug, trace, got %q", c.App.LogLevel)
+	}
+
+	// Connection target
+	db := c.Database
+	if db.URL != "" {
+		if _, err := pgconn.ParseConfig(db.URL); err != nil {
+			fail("database.url", "is not a valid connection string")
+		}
+	} else {
+		if db.Host == "" {
+			fail("database.host", "is required")
+		}
+		if !validPort(db.Port) {
+			fail("database.port", "must be an integer between 1 and 65535, got %q", db.Port)
+		}
+		if db.Username == "" {
+			fail("database.user", "is required")
+		}
+		if db.Database == "" {
+			fail("database.name", "is required")
+		}
+		if !oneOf(db.SSLMode, sslModes) {
+			fail("database.sslmode", "must be one of %s, got %q", strings.Join(sslModes, ", "), db.SSLMode)
+		}
+		if (db.SSLCert == "") != (db.SSLKey == "") {
+			fail("database.sslcert", "and database.sslkey must be set together")
+		}
+	}
+
+	// Pool and logging
+	if db.MaxIdleConns < 0 {
+		fail("database.max_idle_conns", "must not be negative")
+	}
+	if db.MaxOpenConns < 0 {
+		fail("database.max_open_conns", "must not be negative")
+	}
+	if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		fail("database.max_idle_conns", "must not exceed database.max_open_conns (%d)", db.MaxOpenConns)
+	}
+	for key, value := range map[string]time.Duration{
+		"database.conn_max_lifetime":  db.ConnMaxLifetime,
+		"database.conn_max_idle_time": db.ConnMaxIdleTime,
+		"database.query_timeout":      db.QueryTimeout,
+		"database.slow_threshold":     db.SlowThreshold,
+	} {
+		if value < 0 {
+			fail(key, "must not be negative")
+		}
+	}
+	if !oneOf(strings.ToLower(db.LogLevel), logLevels) {
+		fail("database.log_level", "must be one of silent, error, warn, info, got %q", db.LogLevel)
+	}
+
+	// Server
+	if !validPort(c.Server.Port) {
+		fail("server.port", "must be an integer between 1 and 65535, got %q", c.Server.Port)
+	}
+	if c.Server.RateLimit < 0 {
+		fail("server.rate_limit", "must not be negative")
+	}
+	if c.Server.RateLimit > 0 && c.Server.RateLimitBurst < 1 {
+		fail("server.rate_limit_burst", "must be at least 1 when rate limiting is enabled")
+	}
+	for _, origin := range c.Server.CORSOrigins {
+		if !validOrigin(origin) {
+			fail("server.cors_origins", "%q is not an origin such as https://app.example.com or *", origin)
+		}
+	}
+	if c.Server.IdempotencyTTL <= 0 {
+		fail("server.idempotency_ttl", "must be positive")
+	}
+
+	// Auth
+	if c.Auth.JWTSecret != "" && len(c.Auth.JWTSecret) < minJWTSecretLength {
+		fail("auth.jwt_secret", "must be at least %d bytes", minJWTSecretLength)
+	}
+	if c.Auth.Leeway < 0 {
+		fail("auth.leeway", "must not be negative")
+	}
+
+	return errors.Join(sortErrors(errs)...)
+}
+
+// validPort reports whether a string is a usable TCP port
+func validPort(port string) bool {
+	value, err := strconv.Atoi(port)
+	return err == nil && value >= 1 && value <= 65535
+}
+
+// validOrigin reports whether a value is "*" or a scheme://host[:port] origin
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" &&
+		parsed.Path == "" && parsed.RawQuery == "" && parsed.User == nil
+}
+
+// oneOf reports whether value is in the allowed list
+func oneOf(value string, allowed []string) bool {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/auth"
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// APIKeyHandler serves the API key management endpoints of a user
+type APIKeyHandler struct {
+	repo repository.APIKeyRepository
+	now  func() time.Time
+}
+
+// NewAPIKeyHandler creates an API key handler backed by the given repository
+func NewAPIKeyHandler(repo repository.APIKeyRepository) *APIKeyHandler {
+	return &APIKeyHandler{repo: repo, now: time.Now}
+}
+
+// APIKeyRequest is the body of an issue request
+type APIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// Scopes are the permissions granted to callers using the key
+	Scopes    []string   `json:"scopes" validate:"dive,scope"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,future"`
+}
+
+// APIKeyResponse describes a key. Key holds the secret and is only set when
+// a key is issued or rotated; it cannot be retrieved later.
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Key        string     `json:"key,omitempty"`
+}
+
+// newAPIKeyResponse renders a stored key without its secret
+func newAPIKeyResponse(key *models.APIKey) APIKeyResponse {
+	scopes := key.ScopeList()
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return APIKeyResponse{
+		ID:         key.ID,
+		UserID:     key.UserID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// validate normalizes and checks an issue request
+func (r *APIKeyRequest) validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	return validation.Struct(r)
+}
+
+// respondAPIKeyLookupError writes the response for a failed API key lookup
+func respondAPIKeyLookupError(c *gin.Context, operation string, userID, keyID uint, err error, failure string) {
+	notFound := "API key not found"
+	if keyID == 0 {
+		notFound = "User not found"
+	}
+	respondDBError(c, operation, err, notFound, failure, logrus.Fields{
+		"user_id":    userID,
+		"api_key_id": keyID,
+	})
+}
+
+// Create handles issuing a new API key for a user
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	userID, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "create_api_key",
+		"user_id":    userID,
+	}).Info("Processing create API key request")
+
+	var request APIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.LogValidationError(c, "api_key_data", request, err, logrus.Fields{
+			"operation": "create_api_key",
+		})
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
+		})
+		return
+	}
+	if err := request.validate(); err != nil {
+		utils.LogValidationError(c, "api_key_data", request, err)
+		respondValidationError(c, err)
+		return
+	}
+
+	generated, err := auth.GenerateAPIKey()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	key := models.APIKey{
+		UserID:    userID,
+		Name:      request.Name,
+		Prefix:    generated.Prefix,
+		Hash:      generated.Hash,
+		Scopes:    strings.Join(request.Scopes, " "),
+		ExpiresAt: request.ExpiresAt,
+	}
+	if err := h.repo.Create(c.Request.Context(), &key); err != nil {
+		respondAPIKeyLookupError(c, "create_api_key", userID, 0, err, "Failed to create API key")
+		return
+	}
+
+	// Log successful creation; never log the key itself
+	logger.WithFields(logrus.Fields{
+		"request_id":     requestID,
+		"operation":      "create_api_key",
+		"user_id":        userID,
+		"api_key_id":     key.ID,
+		"api_key_prefix": key.Prefix,
+	}).Info("API key created successfully")
+
+	response := newAPIKeyResponse(&key)
+	response.Key = generated.Key
+	c.JSON(http.StatusCreated, response)
+}
+
+// List handles listing a user's API keys
+func (h *APIKeyHandler) List(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	keys, err := h.repo.List(c.Request.Context(), userID)
+	if err != nil {
+		respondAPIKeyLookupError(c, "list_api_keys", userID, 0, err, "Failed to list API keys")
+		return
+	}
+
+	response := make([]APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		response = append(response, newAPIKeyResponse(&keys[i]))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// Rotate handles replacing a key with a new secret. The replacement keeps the
+// name, scopes and expiry; the old key stops working immediately.
+func (h *APIKeyHandler) Rotate(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	userID, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+	keyID, ok := parseIDParam(c, "key_id", "API key")
+	if !ok {
+		return
+	}
+
+	current, err := h.repo.Get(c.Request.Context(), userID, keyID)
+	if err != nil {
+		respondAPIKeyLookupError(c, "rotate_api_key", userID, keyID, err, "Failed to rotate API key")
+		return
+	}
+
+	generated, err := auth.GenerateAPIKey()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	replacement := models.APIKey{
+		UserID:    userID,
+		Name:      current.Name,
+		Prefix:    generated.Prefix,
+		Hash:      generated.Hash,
+		Scopes:    current.Scopes,
+		ExpiresAt: current.ExpiresAt,
+	}
+	err = h.repo.Rotate(c.Request.Context(), userID, keyID, &replacement, h.now())
+	if errors.Is(err, repository.ErrRevoked) {
+		utils.LogBusinessError(c, "rotate_api_key", err, logrus.Fields{
+			"user_id":    userID,
+			"api_key_id": keyID,
+		})
+		middleware.RespondError(c, http.StatusConflict, middleware.ErrorResponse{
+			Error: "API key is revoked",
+			Code:  middleware.CodeAPIKeyRevoked,
+		})
+		return
+	}
+	if err != nil {
+		respondAPIKeyLookupError(c, "rotate_api_key", userID, keyID, err, "Failed to rotate API key")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id":         requestID,
+		"operation":          "rotate_api_key",
+		"user_id":            userID,
+		"api_key_id":         keyID,
+		"replacement_key_id": replacement.ID,
+		"api_key_prefix":     replacement.Prefix,
+	}).Info("API key rotated successfully")
+
+	response := newAPIKeyResponse(&replacement)
+	response.Key = generated.Key
+	c.JSON(http.StatusCreated, response)
+}
+
+// Revoke handles revoking a key; revoking a revoked key succeeds
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	userID, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+	keyID, ok := parseIDParam(c, "key_id", "API key")
+	if !ok {
+		return
+	}
+
+	if _, err := h.repo.Revoke(c.Request.Context(), userID, keyID, h.now()); err != nil {
+		respondAPIKeyLookupError(c, "revoke_api_key", userID, keyID, err, "Failed to revoke API key")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "revoke_api_key",
+		"user_id":    userID,
+		"api_key_id": keyID,
+	}).Info("API key revoked successfully")
+
+	c.Status(http.StatusNoContent)
+}
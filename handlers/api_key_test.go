@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/auth"
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+// setupAPIKeyRouter creates a test router serving the API key routes for user 1
+func setupAPIKeyRouter() (*gin.Engine, *repository.MemoryAPIKeyRepository) {
+	repo := repository.NewMemoryAPIKeyRepository(models.User{Base: models.Base{ID: 1}, Name: "Billing", Email: "billing@example.com"})
+	h := NewAPIKeyHandler(repo)
+
+	router := setupTestRouter()
+	router.GET("/users/:id/api-keys", h.List)
+	router.POST("/users/:id/api-keys", h.Create)
+	router.POST("/users/:id/api-keys/:key_id/rotate", h.Rotate)
+	router.DELETE("/users/:id/api-keys/:key_id", h.Revoke)
+
+	return router, repo
+}
+
+func TestAPIKeyHandler_Lifecycle(t *testing.T) {
+	router, repo := setupAPIKeyRouter()
+	verifier := auth.NewAPIKeyVerifier(repo)
+
+	// Issue
+	w := performRequest(router, http.MethodPost, "/users/1/api-keys", []byte(`{"name":"billing sync","scopes":["employees:read"]}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var issued APIKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+	assert.NotEmpty(t, issued.Key)
+	assert.Equal(t, []string{"employees:read"}, issued.Scopes)
+
+	principal, err := verifier.Verify(context.Background(), issued.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "billing@example.com", principal.Subject)
+
+	// List never exposes secrets
+	w = performRequest(router, http.MethodGet, "/users/1/api-keys", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), issued.Key)
+	assert.Contains(t, w.Body.String(), issued.Prefix)
+
+	// Rotate replaces the secret and invalidates the old key
+	w = performRequest(router, http.MethodPost, fmt.Sprintf("/users/1/api-keys/%d/rotate", issued.ID), nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var rotated APIKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+	assert.NotEqual(t, issued.Key, rotated.Key)
+	assert.Equal(t, issued.Name, rotated.Name)
+
+	_, err = verifier.Verify(context.Background(), issued.Key)
+	assert.ErrorIs(t, err, auth.ErrAPIKeyInactive)
+	_, err = verifier.Verify(context.Background(), rotated.Key)
+	assert.NoError(t, err)
+
+	w = performRequest(router, http.MethodPost, fmt.Sprintf("/users/1/api-keys/%d/rotate", issued.ID), nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"error":"API key is revoked","code":"api_key_revoked"}`, w.Body.String())
+
+	// Revoke
+	w = performRequest(router, http.MethodDelete, fmt.Sprintf("/users/1/api-keys/%d", rotated.ID), nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	_, err = verifier.Verify(context.Background(), rotated.Key)
+	assert.ErrorIs(t, err, auth.ErrAPIKeyInactive)
+}
+
+func TestAPIKeyHandler_Errors(t *testing.T) {
+	router, _ := setupAPIKeyRouter()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		error  string
+		code   string
+	}{
+		{name: "unknown user", method: http.MethodPost, path: "/users/2/api-keys", body: `{"name":"x"}`, status: http.StatusNotFound, error: "User not found", code: "not_found"},
+		{name: "missing name", method: http.MethodPost, path: "/users/1/api-keys", body: `{"scopes":["employees:read"]}`, status: http.StatusBadRequest, error: "name is required", code: "validation_failed"},
+		{name: "invalid scope", method: http.MethodPost, path: "/users/1/api-keys", body: `{"name":"x","scopes":["a b"]}`, status: http.StatusBadRequest, error: "scopes[0] must be a non-empty scope without whitespace", code: "validation_failed"},
+		{name: "expiry in the past", method: http.MethodPost, path: "/users/1/api-keys", body: `{"name":"x","expires_at":"2000-01-01T00:00:00Z"}`, status: http.StatusBadRequest, error: "expires_at must be in the future", code: "validation_failed"},
+		{name: "unknown key", method: http.MethodDelete, path: "/users/1/api-keys/99", status: http.StatusNotFound, error: "API key not found", code: "not_found"},
+		{name: "invalid key id", method: http.MethodDelete, path: "/users/1/api-keys/abc", status: http.StatusBadRequest, error: "Invalid API key ID", code: "validation_failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			if tt.body != "" {
+				body = []byte(tt.body)
+			}
+			w := performRequest(router, tt.method, tt.path, body)
+			assert.Equal(t, tt.status, w.Code)
+			expected, err := json.Marshal(middleware.ErrorResponse{Error: tt.error, Code: tt.code})
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// DepartmentHandler serves the department endpoints
+type DepartmentHandler struct {
+	repo      repository.DepartmentRepository
+	employees repository.EmployeeRepository
+}
+
+// NewDepartmentHandler creates a department handler backed by the given
+// repositories; employees holds the members of the departments
+func NewDepartmentHandler(repo repository.DepartmentRepository, employees repository.EmployeeRepository) *DepartmentHandler {
+	return &DepartmentHandler{repo: repo, employees: employees}
+}
+
+// respondDepartmentLookupError writes the response for a failed department lookup
+func respondDepartmentLookupError(c *gin.Context, operation string, id uint, err error, failure string) {
+	respondDBError(c, operation, err, "Department not found", failure, logrus.Fields{
+		"department_id": id,
+	})
+}
+
+// respondDepartmentNameConflict writes the response for a write rejected by the unique name index
+func respondDepartmentNameConflict(c *gin.Context, operation string, name string, err error) {
+	utils.LogBusinessError(c, operation, err, logrus.Fields{
+		"department_name": name,
+	})
+	middleware.RespondError(c, http.StatusConflict, middleware.ErrorResponse{
+		Error: "A department with this name already exists",
+		Code:  utils.ErrCodeDuplicate,
+	})
+}
+
+// bindDepartment binds, normalizes and validates a department body. On
+// failure it writes a 400 response and returns false.
+func bindDepartment(c *gin.Context, operation string) (*models.Department, bool) {
+	var department models.Department
+	if err := c.ShouldBindJSON(&department); err != nil {
+		utils.LogValidationError(c, "department_data", department, err, logrus.Fields{
+			"operation": operation,
+		})
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
+		})
+		return nil, false
+	}
+	department.Name = strings.TrimSpace(department.Name)
+	department.Description = strings.TrimSpace(department.Description)
+	if err := validation.Struct(&department); err != nil {
+		utils.LogValidationError(c, "department_data", department, err, logrus.Fields{
+			"operation": operation,
+		})
+		respondValidationError(c, err)
+		return nil, false
+	}
+	return &department, true
+}
+
+// Create handles the creation of a new department
+func (h *DepartmentHandler) Create(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	department, ok := bindDepartment(c, "create_department")
+	if !ok {
+		return
+	}
+
+	err := h.repo.Create(c.Request.Context(), department)
+	if errors.Is(err, repository.ErrDuplicate) {
+		respondDepartmentNameConflict(c, "create_department", department.Name, err)
+		return
+	}
+	if err != nil {
+		respondDBError(c, "create_department", err, "", "Failed to create department")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id":    requestID,
+		"operation":     "create_department",
+		"department_id": department.ID,
+	}).Info("Department created successfully")
+
+	c.JSON(http.StatusCreated, department)
+}
+
+// Get handles retrieving a department by ID
+func (h *DepartmentHandler) Get(c *gin.Context) {
+	departmentID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	department, err := h.repo.Get(c.Request.Context(), departmentID)
+	if err != nil {
+		respondDepartmentLookupError(c, "get_department", departmentID, err, "Failed to retrieve department")
+		return
+	}
+
+	c.JSON(http.StatusOK, department)
+}
+
+// List handles listing departments with pagination
+func (h *DepartmentHandler) List(c *gin.Context) {
+	page, err := parsePage(c)
+	if err != nil {
+		utils.LogValidationError(c, "query", c.Request.URL.RawQuery, err, logrus.Fields{
+			"operation": "list_departments",
+		})
+		respondValidationError(c, err)
+		return
+	}
+
+	list, err := h.repo.List(c.Request.Context(), page)
+	if err != nil {
+		respondDBError(c, "list_departments", err, "", "Failed to list departments")
+		return
+	}
+
+	// Always render an array, never null
+	departments := list.Departments
+	if departments == nil {
+		departments = []models.Department{}
+	}
+
+	c.JSON(http.StatusOK, ListResponse{
+		Data:   departments,
+		Total:  list.Total,
+		Limit:  page.Limit,
+		Offset: &page.Offset,
+		Links:  pageLinks(c, page, list.HasMore),
+	})
+}
+
+// Update handles replacing the name and description of a department
+func (h *DepartmentHandler) Update(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	departmentID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	replacement, ok := bindDepartment(c, "update_department")
+	if !ok {
+		return
+	}
+
+	department, err := h.repo.Update(c.Request.Context(), departmentID, replacement)
+	if errors.Is(err, repository.ErrDuplicate) {
+		respondDepartmentNameConflict(c, "update_department", replacement.Name, err)
+		return
+	}
+	if err != nil {
+		respondDepartmentLookupError(c, "update_department", departmentID, err, "Failed to update department")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id":    requestID,
+		"operation":     "update_department",
+		"department_id": department.ID,
+	}).Info("Department updated successfully")
+
+	c.JSON(http.StatusOK, department)
+}
+
+// Delete handles soft-deleting a department. A department with employees is
+// only deleted when reassign_to names the department they move to.
+func (h *DepartmentHandler) Delete(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	departmentID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	var reassignTo uint
+	if raw := c.Query("reassign_to"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 0)
+		if err != nil || id == 0 || uint(id) == departmentID {
+			err := validation.FieldError{Field: "reassign_to", Reason: "must be the ID of another department"}
+			utils.LogValidationError(c, "reassign_to", raw, err, logrus.Fields{
+				"operation": "delete_department",
+			})
+			respondValidationError(c, err)
+			return
+		}
+		reassignTo = uint(id)
+	}
+
+	moved, err := h.repo.Delete(c.Request.Context(), departmentID, reassignTo)
+	switch {
+	case errors.Is(err, repository.ErrInUse):
+		utils.LogBusinessError(c, "delete_department", err, logrus.Fields{
+			"department_id": departmentID,
+		})
+		middleware.RespondError(c, http.StatusConflict, middleware.ErrorResponse{
+			Error: "Department still has employees; pass reassign_to to move them to another department",
+			Code:  middleware.CodeResourceInUse,
+		})
+		return
+	case errors.Is(err, repository.ErrInvalidReference):
+		utils.LogValidationError(c, "reassign_to", reassignTo, err, logrus.Fields{
+			"operation": "delete_department",
+		})
+		middleware.RespondError(c, http.StatusUnprocessableEntity, middleware.ErrorResponse{
+			Error:         "Department to reassign employees to not found",
+			Code:          utils.ErrCodeForeignKeyViolation,
+			InvalidParams: []middleware.InvalidParam{{Name: "reassign_to", Reason: "must be the ID of another department"}},
+		})
+		return
+	case err != nil:
+		respondDepartmentLookupError(c, "delete_department", departmentID, err, "Failed to delete department")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id":    requestID,
+		"operation":     "delete_department",
+		"department_id": departmentID,
+		"reassign_to":   reassignTo,
+		"moved":         moved,
+	}).Info("Department deleted successfully")
+
+	c.Status(http.StatusNoContent)
+}
+
+// Employees handles listing the employees of a department, with the
+// filtering, sorting and pagination of GET /employees
+func (h *DepartmentHandler) Employees(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	departmentID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	params, err := parseEmployeeListParams(c)
+	if err != nil {
+		utils.LogValidationError(c, "query", c.Request.URL.RawQuery, err, logrus.Fields{
+			"operation": "list_department_employees",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	params.Options.Filter.DepartmentID = departmentID
+
+	// An unknown department is a 404 rather than an empty list
+	if _, err := h.repo.Get(c.Request.Context(), departmentID); err != nil {
+		respondDepartmentLookupError(c, "list_department_employees", departmentID, err, "Failed to list employees")
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id":    requestID,
+		"operation":     "list_department_employees",
+		"department_id": departmentID,
+		"pagination":    params.Mode,
+		"limit":         params.Options.Limit,
+	}).Info("Processing list department employees request")
+
+	respondEmployeeList(c, h.employees, params, "list_department_employees")
+}
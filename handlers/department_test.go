@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/patch"
+	"github.com/yourname/employee-api/repository"
+)
+
+// setupDepartmentRouter creates a test router serving the department and
+// employee routes from in-memory repositories
+func setupDepartmentRouter() (*gin.Engine, *repository.MemoryEmployeeRepository) {
+	router, employeeRepo := setupEmployeeRouter()
+	departments := NewDepartmentHandler(repository.NewMemoryDepartmentRepository(employeeRepo), employeeRepo)
+
+	router.GET("/departments", departments.List)
+	router.POST("/departments", departments.Create)
+	router.GET("/departments/:id", departments.Get)
+	router.PUT("/departments/:id", departments.Update)
+	router.DELETE("/departments/:id", departments.Delete)
+	router.GET("/departments/:id/employees", departments.Employees)
+
+	return router, employeeRepo
+}
+
+// createDepartment creates a department through the API and returns it
+func createDepartment(t *testing.T, router *gin.Engine, name string) models.Department {
+	t.Helper()
+	w := performRequest(router, http.MethodPost, "/departments", []byte(fmt.Sprintf(`{"name":%q}`, name)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var department models.Department
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &department))
+	return department
+}
+
+// createMember creates an employee of a department through the API and returns it
+func createMember(t *testing.T, router *gin.Engine, first, last string, departmentID uint) models.Employee {
+	t.Helper()
+	body := fmt.Sprintf(`{"first_name":%q,"last_name":%q,"department_id":%d}`, first, last, departmentID)
+	w := performRequest(router, http.MethodPost, "/employees", []byte(body))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var employee models.Employee
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &employee))
+	return employee
+}
+
+// departmentMembers lists the last names of a department's employees through the API
+func departmentMembers(t *testing.T, router *gin.Engine, departmentID uint) []string {
+	t.Helper()
+	w := performRequest(router, http.MethodGet, fmt.Sprintf("/departments/%d/employees?sort=last_name", departmentID), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var list struct {
+		Data []models.Employee `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	names := []string{}
+	for _, employee := range list.Data {
+		names = append(names, employee.LastName)
+	}
+	return names
+}
+
+func TestDepartmentHandler_CRUD(t *testing.T) {
+	router, _ := setupDepartmentRouter()
+
+	department := createDepartment(t, router, " Engineering ")
+	assert.Equal(t, "Engineering", department.Name)
+
+	w := performRequest(router, http.MethodPut, fmt.Sprintf("/departments/%d", department.ID), []byte(`{"name":"Research","description":"Analytical engines"}`))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"name":"Research"`)
+	assert.Contains(t, w.Body.String(), `"description":"Analytical engines"`)
+
+	w = performRequest(router, http.MethodGet, "/departments?limit=1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	w = performRequest(router, http.MethodDelete, fmt.Sprintf("/departments/%d", department.ID), nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = performRequest(router, http.MethodGet, fmt.Sprintf("/departments/%d", department.ID), nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"Department not found","code":"not_found"}`, w.Body.String())
+
+	// The name is free again once the department is deleted
+	createDepartment(t, router, "Research")
+}
+
+func TestDepartmentHandler_Validation(t *testing.T) {
+	router, _ := setupDepartmentRouter()
+	createDepartment(t, router, "Engineering")
+
+	w := performRequest(router, http.MethodPost, "/departments", []byte(`{"name":"  "}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"name is required","code":"validation_failed"}`, w.Body.String())
+
+	w = performRequest(router, http.MethodPost, "/departments", []byte(`{"name":"Engineering"}`))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"error":"A department with this name already exists","code":"duplicate"}`, w.Body.String())
+}
+
+func TestDepartmentHandler_Employees(t *testing.T) {
+	router, repo := setupDepartmentRouter()
+	engineering := createDepartment(t, router, "Engineering")
+	research := createDepartment(t, router, "Research")
+	createMember(t, router, "Grace", "Hopper", engineering.ID)
+	createMember(t, router, "Ada", "Lovelace", research.ID)
+	turing := createMember(t, router, "Alan", "Turing", engineering.ID)
+	seedEmployees(t, repo, [2]string{"Charles", "Babbage"})
+
+	assert.Equal(t, []string{"Hopper", "Turing"}, departmentMembers(t, router, engineering.ID))
+	assert.Equal(t, []string{"Lovelace"}, departmentMembers(t, router, research.ID))
+
+	// Moving an employee changes the membership
+	body := fmt.Sprintf(`{"first_name":"Alan","last_name":"Turing","department_id":%d}`, research.ID)
+	w := performRequest(router, http.MethodPut, fmt.Sprintf("/employees/%d", turing.ID), []byte(body))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"Lovelace", "Turing"}, departmentMembers(t, router, research.ID))
+
+	// The list endpoint filters by department too
+	w = performRequest(router, http.MethodGet, fmt.Sprintf("/employees?department_id=%d", engineering.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	w = performRequest(router, http.MethodGet, "/departments/99/employees", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"Department not found","code":"not_found"}`, w.Body.String())
+}
+
+func TestDepartmentHandler_EmployeeDepartmentMustExist(t *testing.T) {
+	router, _ := setupDepartmentRouter()
+	department := createDepartment(t, router, "Engineering")
+	ada := createMember(t, router, "Ada", "Lovelace", department.ID)
+
+	w := performRequest(router, http.MethodPost, "/employees", []byte(`{"first_name":"Grace","last_name":"Hopper","department_id":99}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"foreign_key_violation"`)
+
+	w = performPatch(router, fmt.Sprintf("/employees/%d", ada.ID), patch.MergePatchContentType, `{"department_id":99}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// Clearing the department is always allowed
+	w = performPatch(router, fmt.Sprintf("/employees/%d", ada.ID), patch.MergePatchContentType, `{"department_id":null}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "department_id")
+}
+
+func TestDepartmentHandler_Delete(t *testing.T) {
+	router, repo := setupDepartmentRouter()
+	engineering := createDepartment(t, router, "Engineering")
+	research := createDepartment(t, router, "Research")
+	hopper := createMember(t, router, "Grace", "Hopper", engineering.ID)
+	turing := createMember(t, router, "Alan", "Turing", engineering.ID)
+	require.NoError(t, repo.Delete(context.Background(), turing.ID, 0))
+	path := fmt.Sprintf("/departments/%d", engineering.ID)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		want   string
+	}{
+		{
+			name: "has employees", status: http.StatusConflict,
+			want: `{"error":"Department still has employees; pass reassign_to to move them to another department","code":"resource_in_use"}`,
+		},
+		{
+			name: "reassign to itself", query: fmt.Sprintf("?reassign_to=%d", engineering.ID), status: http.StatusBadRequest,
+			want: `{"error":"reassign_to must be the ID of another department","code":"validation_failed"}`,
+		},
+		{
+			name: "reassign to unknown department", query: "?reassign_to=99", status: http.StatusUnprocessableEntity,
+			want: `{"error":"Department to reassign employees to not found","code":"foreign_key_violation"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodDelete, path+tt.query, nil)
+			assert.Equal(t, tt.status, w.Code)
+			assert.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+
+	w := performRequest(router, http.MethodDelete, fmt.Sprintf("%s?reassign_to=%d", path, research.ID), nil)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	assert.Equal(t, []string{"Hopper"}, departmentMembers(t, router, research.ID))
+
+	// Deleted employees move too, and every moved employee gets a new version
+	for _, id := range []uint{hopper.ID, turing.ID} {
+		employee, err := repo.Get(context.Background(), id, true)
+		require.NoError(t, err)
+		require.NotNil(t, employee.DepartmentID)
+		assert.Equal(t, research.ID, *employee.DepartmentID)
+		assert.Equal(t, uint(2), employee.Version)
+	}
+
+	// Without current employees a department is deleted right away
+	w = performRequest(router, http.MethodDelete, fmt.Sprintf("/employees/%d", hopper.ID), nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = performRequest(router, http.MethodDelete, fmt.Sprintf("/departments/%d", research.ID), nil)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	employee, err := repo.Get(context.Background(), hopper.ID, true)
+	require.NoError(t, err)
+	assert.Nil(t, employee.DepartmentID)
+}
@@ -2,23 +2,87 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
 
-	"github.com/yourname/employee-api/models"
-	"github.com/yourname/employee-api/config"
 	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
 	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
 )
 
-// CreateEmployeeHandler handles the creation of a new employee
-func CreateEmployeeHandler(c *gin.Context) {
+// EmployeeHandler serves the employee endpoints
+type EmployeeHandler struct {
+	repo repository.EmployeeRepository
+}
+
+// NewEmployeeHandler creates an employee handler backed by the given repository
+func NewEmployeeHandler(repo repository.EmployeeRepository) *EmployeeHandler {
+	return &EmployeeHandler{repo: repo}
+}
+
+// parseEmployeeID extracts and validates the employee ID URL parameter.
+// On failure it writes a 400 response and returns false.
+func parseEmployeeID(c *gin.Context) (uint, bool) {
+	return parseIDParam(c, "id", "employee")
+}
+
+// parseIDParam extracts and validates a positive integer ID URL parameter.
+// On failure it writes a 400 response naming the resource and returns false.
+func parseIDParam(c *gin.Context, param, resource string) (uint, bool) {
+	raw := c.Param(param)
+	field := strings.ReplaceAll(strings.ToLower(resource), " ", "_") + "_id"
+	if raw == "" {
+		err := fmt.Errorf("%s ID is required", resource)
+		utils.LogValidationError(c, field, raw, err)
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error:         capitalize(resource) + " ID is required",
+			Code:          middleware.CodeValidationFailed,
+			InvalidParams: []middleware.InvalidParam{{Name: param, Reason: "is required"}},
+		})
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 0)
+	if err != nil || id == 0 {
+		utils.LogValidationError(c, field, raw, fmt.Errorf("%s ID must be a positive integer", resource))
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error:         "Invalid " + resource + " ID",
+			Code:          middleware.CodeValidationFailed,
+			InvalidParams: []middleware.InvalidParam{{Name: param, Reason: "must be a positive integer"}},
+		})
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+// capitalize upper-cases the first letter of an ASCII word
+func capitalize(word string) string {
+	if word == "" {
+		return word
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+// respondEmployeeLookupError writes the response for a failed employee lookup
+func respondEmployeeLookupError(c *gin.Context, operation string, id uint, err error, failure string) {
+	respondDBError(c, operation, err, "Employee not found", failure, logrus.Fields{
+		"employee_id": id,
+	})
+}
+
+// Create handles the creation of a new employee
+func (h *EmployeeHandler) Create(c *gin.Context) {
 	logger := utils.GetLogger()
 	requestID := middleware.GetRequestID(c)
-	
+
 	// Log request start
 	logger.WithFields(logrus.Fields{
 		"request_id": requestID,
@@ -33,48 +97,31 @@ func CreateEmployeeHandler(c *gin.Context) {
 		utils.LogValidationError(c, "employee_data", employee, err, logrus.Fields{
 			"operation": "create_employee",
 		})
-		
+
 		// Return standardized error response
-		c.JSON(http.StatusBadRequest, middleware.ErrorResponse{
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
 			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
 		})
 		return
 	}
 
-	// Validate required fields
-	if employee.FirstName == "" {
-		err := errors.New("first_name is required")
-		utils.LogValidationError(c, "first_name", employee.FirstName, err)
-		c.JSON(http.StatusBadRequest, middleware.ErrorResponse{
-			Error: "first_name is required",
-		})
-		return
-	}
-
-	if employee.LastName == "" {
-		err := errors.New("last_name is required")
-		utils.LogValidationError(c, "last_name", employee.LastName, err)
-		c.JSON(http.StatusBadRequest, middleware.ErrorResponse{
-			Error: "last_name is required",
+	// Validate against the model's rules, reporting every invalid field
+	if err := validation.Struct(&employee); err != nil {
+		utils.LogValidationError(c, "employee_data", employee, err, logrus.Fields{
+			"operation": "create_employee",
 		})
+		respondValidationError(c, err)
 		return
 	}
 
-	// Get database connection
-	db := config.GetDB()
-
 	// Insert into database
-	if err := db.Create(&employee).Error; err != nil {
-		// Log database error with context
-		utils.LogDBError(c, "create_employee", err, logrus.Fields{
+	if err := h.repo.Create(c.Request.Context(), &employee); err != nil {
+		// Log database error with context and return its translated response
+		respondDBError(c, "create_employee", err, "", "Failed to create employee", logrus.Fields{
 			"employee_first_name": employee.FirstName,
 			"employee_last_name":  employee.LastName,
 		})
-		
-		// Return standardized error response
-		c.JSON(http.StatusInternalServerError, middleware.ErrorResponse{
-			Error: "Failed to create employee",
-		})
 		return
 	}
 
@@ -88,54 +135,40 @@ func CreateEmployeeHandler(c *gin.Context) {
 	}).Info("Employee created successfully")
 
 	// Return created employee
-	c.JSON(http.StatusCreated, employee)
+	c.Header("ETag", employeeETag(&employee))
+	middleware.RestrictedJSON(c, http.StatusCreated, employee)
 }
 
-// GetEmployeeHandler handles retrieving an employee by ID
-func GetEmployeeHandler(c *gin.Context) {
+// Get handles retrieving an employee by ID
+func (h *EmployeeHandler) Get(c *gin.Context) {
 	logger := utils.GetLogger()
 	requestID := middleware.GetRequestID(c)
-	
+
 	// Get employee ID from URL parameter
-	employeeID := c.Param("id")
-	if employeeID == "" {
-		err := errors.New("employee ID is required")
-		utils.LogValidationError(c, "employee_id", employeeID, err)
-		c.JSON(http.StatusBadRequest, middleware.ErrorResponse{
-			Error: "Employee ID is required",
-		})
+	employeeID, ok := parseEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	includeDeleted, err := parseBoolQuery(c, "include_deleted")
+	if err != nil {
+		utils.LogValidationError(c, "include_deleted", c.Query("include_deleted"), err)
+		respondValidationError(c, err)
 		return
 	}
 
 	// Log request start
 	logger.WithFields(logrus.Fields{
-		"request_id":  requestID,
-		"operation":   "get_employee",
-		"employee_id": employeeID,
+		"request_id":      requestID,
+		"operation":       "get_employee",
+		"employee_id":     employeeID,
+		"include_deleted": includeDeleted,
 	}).Info("Processing get employee request")
 
-	var employee models.Employee
-	db := config.GetDB()
-
 	// Find employee by ID
-	if err := db.First(&employee, employeeID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			// Log warning for not found
-			utils.LogDBError(c, "get_employee", err, logrus.Fields{
-				"employee_id": employeeID,
-			})
-			c.JSON(http.StatusNotFound, middleware.ErrorResponse{
-				Error: "Employee not found",
-			})
-		} else {
-			// Log database error
-			utils.LogDBError(c, "get_employee", err, logrus.Fields{
-				"employee_id": employeeID,
-			})
-			c.JSON(http.StatusInternalServerError, middleware.ErrorResponse{
-				Error: "Failed to retrieve employee",
-			})
-		}
+	employee, err := h.repo.Get(c.Request.Context(), employeeID, includeDeleted)
+	if err != nil {
+		respondEmployeeLookupError(c, "get_employee", employeeID, err, "Failed to retrieve employee")
 		return
 	}
 
@@ -148,22 +181,126 @@ func GetEmployeeHandler(c *gin.Context) {
 		"employee_last_name":  employee.LastName,
 	}).Info("Employee retrieved successfully")
 
-	c.JSON(http.StatusOK, employee)
+	// A client holding the current version gets no body
+	etag := employeeETag(employee)
+	c.Header("ETag", etag)
+	if header := c.GetHeader("If-None-Match"); header != "" && etagListMatches(header, etag, true) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	middleware.RestrictedJSON(c, http.StatusOK, employee)
 }
 
-// UpdateEmployeeHandler handles updating an employee
-func UpdateEmployeeHandler(c *gin.Context) {
+// List handles listing employees with filtering, sorting and pagination
+func (h *EmployeeHandler) List(c *gin.Context) {
 	logger := utils.GetLogger()
 	requestID := middleware.GetRequestID(c)
-	
-	// Get employee ID from URL parameter
-	employeeID := c.Param("id")
-	if employeeID == "" {
-		err := errors.New("employee ID is required")
-		utils.LogValidationError(c, "employee_id", employeeID, err)
-		c.JSON(http.StatusBadRequest, middleware.ErrorResponse{
-			Error: "Employee ID is required",
+
+	// Parse and validate query parameters
+	params, err := parseEmployeeListParams(c)
+	if err != nil {
+		utils.LogValidationError(c, "query", c.Request.URL.RawQuery, err, logrus.Fields{
+			"operation": "list_employees",
 		})
+		respondValidationError(c, err)
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "list_employees",
+		"pagination": params.Mode,
+		"limit":      params.Options.Limit,
+	}).Info("Processing list employees request")
+
+	respondEmployeeList(c, h.repo, params, "list_employees")
+}
+
+// respondEmployeeList lists a page of employees and writes it with the links
+// of its pagination mode
+func respondEmployeeList(c *gin.Context, repo repository.EmployeeRepository, params *EmployeeListParams, operation string) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	list, err := repo.List(c.Request.Context(), params.Options)
+	if err != nil {
+		respondDBError(c, operation, err, "", "Failed to list employees")
+		return
+	}
+
+	// Always render an array, never null
+	employees := list.Employees
+	if employees == nil {
+		employees = []models.Employee{}
+	}
+
+	response := ListResponse{
+		Data:  employees,
+		Total: list.Total,
+		Limit: params.Options.Limit,
+	}
+	if params.Mode == paginationCursor {
+		response.Links = cursorPageLinks(c, params, list)
+	} else {
+		response.Links = offsetPageLinks(c, params, list)
+		response.Offset = &params.Options.Offset
+	}
+
+	// Log successful listing
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  operation,
+		"count":      len(employees),
+		"total":      list.Total,
+	}).Info("Employees listed successfully")
+
+	middleware.RestrictedJSON(c, http.StatusOK, response)
+}
+
+// offsetPageLinks builds the next/prev links of an offset-paginated page
+func offsetPageLinks(c *gin.Context, params *EmployeeListParams, list *repository.EmployeeList) PageLinks {
+	page := repository.Page{Limit: params.Options.Limit, Offset: params.Options.Offset}
+	return pageLinks(c, page, list.HasMore)
+}
+
+// cursorPageLinks builds the next/prev links of a keyset-paginated page
+func cursorPageLinks(c *gin.Context, params *EmployeeListParams, list *repository.EmployeeList) PageLinks {
+	var links PageLinks
+	if len(list.Employees) == 0 {
+		return links
+	}
+
+	cursorLink := func(employee *models.Employee, direction string) string {
+		values := employeeCursorValues(params.Options.Sort, employee)
+		return pageURL(c, map[string]string{
+			"cursor": encodeCursor(params.SortExpr, values, direction),
+		}, "offset")
+	}
+
+	// A backwards page always has rows after it; a forward page has rows
+	// before it whenever it was reached through a cursor
+	backwards := params.Cursor != nil && params.Cursor.Direction == cursorPrev
+	if backwards || list.HasMore {
+		links.Next = cursorLink(&list.Employees[len(list.Employees)-1], cursorNext)
+	}
+	if (backwards && list.HasMore) || (!backwards && params.Cursor != nil) {
+		links.Prev = cursorLink(&list.Employees[0], cursorPrev)
+	}
+
+	return links
+}
+
+// Update handles replacing an employee. Fields missing from the body are
+// cleared, so required fields must be sent; id and timestamps are ignored.
+func (h *EmployeeHandler) Update(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	// Get employee ID from URL parameter
+	employeeID, ok := parseEmployeeID(c)
+	if !ok {
 		return
 	}
 
@@ -182,57 +319,64 @@ func UpdateEmployeeHandler(c *gin.Context) {
 			"operation":   "update_employee",
 			"employee_id": employeeID,
 		})
-		c.JSON(http.StatusBadRequest, middleware.ErrorResponse{
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
 			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
 		})
 		return
 	}
 
-	db := config.GetDB()
-
-	// Check if employee exists first
-	var existingEmployee models.Employee
-	if err := db.First(&existingEmployee, employeeID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			utils.LogDBError(c, "update_employee", err, logrus.Fields{
-				"employee_id": employeeID,
-			})
-			c.JSON(http.StatusNotFound, middleware.ErrorResponse{
-				Error: "Employee not found",
-			})
-		} else {
-			utils.LogDBError(c, "update_employee", err, logrus.Fields{
-				"employee_id": employeeID,
-			})
-			c.JSON(http.StatusInternalServerError, middleware.ErrorResponse{
-				Error: "Failed to retrieve employee",
-			})
-		}
+	// Callers may not change what they cannot see
+	set, err := hiddenFieldsSet(c, &updateData)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
-
-	// Update employee
-	if err := db.Model(&existingEmployee).Updates(updateData).Error; err != nil {
-		utils.LogDBError(c, "update_employee", err, logrus.Fields{
-			"employee_id":         employeeID,
-			"employee_first_name": updateData.FirstName,
-			"employee_last_name":  updateData.LastName,
-		})
-		c.JSON(http.StatusInternalServerError, middleware.ErrorResponse{
-			Error: "Failed to update employee",
+	if len(set) > 0 {
+		utils.LogBusinessError(c, "update_employee", errors.New("update sets hidden fields"), logrus.Fields{
+			"employee_id": employeeID,
+			"fields":      set,
 		})
+		middleware.RespondHiddenFieldsWritten(c, "", set)
 		return
 	}
 
-	// Fetch updated employee
-	var updatedEmployee models.Employee
-	if err := db.First(&updatedEmployee, employeeID).Error; err != nil {
-		utils.LogDBError(c, "update_employee_fetch", err, logrus.Fields{
+	// Validate the replacement as a whole
+	if err := validation.Struct(&updateData); err != nil {
+		utils.LogValidationError(c, "employee_data", updateData, err, logrus.Fields{
+			"operation":   "update_employee",
 			"employee_id": employeeID,
 		})
-		c.JSON(http.StatusInternalServerError, middleware.ErrorResponse{
-			Error: "Failed to retrieve updated employee",
-		})
+		respondValidationError(c, err)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c, "update_employee", employeeID)
+	if !ok {
+		return
+	}
+
+	// A replacement keeps the stored values of fields the caller cannot see,
+	// so it must not overwrite a newer version than the one they came from
+	if len(c.GetStringSlice(middleware.HiddenFieldsKey)) > 0 {
+		current, err := h.repo.Get(c.Request.Context(), employeeID, false)
+		if err != nil {
+			respondEmployeeLookupError(c, "update_employee", employeeID, err, "Failed to update employee")
+			return
+		}
+		if err := keepHiddenFields(c, current, &updateData); err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if version == 0 {
+			version = current.Version
+		}
+	}
+
+	// Update employee
+	updatedEmployee, err := h.repo.Update(c.Request.Context(), employeeID, &updateData, version)
+	if err != nil {
+		respondEmployeeWriteError(c, "update_employee", employeeID, err, "Failed to update employee")
 		return
 	}
 
@@ -245,5 +389,125 @@ func UpdateEmployeeHandler(c *gin.Context) {
 		"employee_last_name":  updatedEmployee.LastName,
 	}).Info("Employee updated successfully")
 
-	c.JSON(http.StatusOK, updatedEmployee)
+	c.Header("ETag", employeeETag(updatedEmployee))
+	middleware.RestrictedJSON(c, http.StatusOK, updatedEmployee)
+}
+
+// Delete handles soft-deleting an employee
+func (h *EmployeeHandler) Delete(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	// Get employee ID from URL parameter
+	employeeID, ok := parseEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id":  requestID,
+		"operation":   "delete_employee",
+		"employee_id": employeeID,
+	}).Info("Processing delete employee request")
+
+	version, ok := h.ifMatchVersion(c, "delete_employee", employeeID)
+	if !ok {
+		return
+	}
+
+	// Soft delete sets deleted_at instead of removing the row
+	if err := h.repo.Delete(c.Request.Context(), employeeID, version); err != nil {
+		respondEmployeeWriteError(c, "delete_employee", employeeID, err, "Failed to delete employee")
+		return
+	}
+
+	// Log successful deletion
+	logger.WithFields(logrus.Fields{
+		"request_id":  requestID,
+		"operation":   "delete_employee",
+		"employee_id": employeeID,
+	}).Info("Employee deleted successfully")
+
+	c.Status(http.StatusNoContent)
+}
+
+// Restore handles restoring a soft-deleted employee
+func (h *EmployeeHandler) Restore(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	// Get employee ID from URL parameter
+	employeeID, ok := parseEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id":  requestID,
+		"operation":   "restore_employee",
+		"employee_id": employeeID,
+	}).Info("Processing restore employee request")
+
+	// Clear deleted_at to bring the row back into default scope
+	employee, err := h.repo.Restore(c.Request.Context(), employeeID)
+	if errors.Is(err, repository.ErrNotDeleted) {
+		utils.LogBusinessError(c, "restore_employee", err, logrus.Fields{
+			"employee_id": employeeID,
+		})
+		middleware.RespondError(c, http.StatusConflict, middleware.ErrorResponse{
+			Error: "Employee is not deleted",
+			Code:  middleware.CodeNotDeleted,
+		})
+		return
+	}
+	if err != nil {
+		respondEmployeeLookupError(c, "restore_employee", employeeID, err, "Failed to restore employee")
+		return
+	}
+
+	// Log successful restore
+	logger.WithFields(logrus.Fields{
+		"request_id":  requestID,
+		"operation":   "restore_employee",
+		"employee_id": employee.ID,
+	}).Info("Employee restored successfully")
+
+	c.Header("ETag", employeeETag(employee))
+	middleware.RestrictedJSON(c, http.StatusOK, employee)
+}
+
+// Purge handles permanently removing an employee, deleted or not
+func (h *EmployeeHandler) Purge(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	// Get employee ID from URL parameter
+	employeeID, ok := parseEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id":  requestID,
+		"operation":   "purge_employee",
+		"employee_id": employeeID,
+	}).Info("Processing purge employee request")
+
+	// Unscoped delete issues a real DELETE statement
+	if err := h.repo.Purge(c.Request.Context(), employeeID); err != nil {
+		respondEmployeeLookupError(c, "purge_employee", employeeID, err, "Failed to purge employee")
+		return
+	}
+
+	// Log successful purge
+	logger.WithFields(logrus.Fields{
+		"request_id":  requestID,
+		"operation":   "purge_employee",
+		"employee_id": employeeID,
+	}).Warn("Employee purged permanently")
+
+	c.Status(http.StatusNoContent)
 }
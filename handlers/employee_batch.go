@@ -0,0 +1,399 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/auth"
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// Modes of an employee batch
+const (
+	// BatchModeAtomic applies every operation in one transaction, or none
+	BatchModeAtomic = "atomic"
+	// BatchModeBestEffort applies operations independently and reports each outcome
+	BatchModeBestEffort = "best_effort"
+)
+
+// Operations of an employee batch
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// batchAction is the custom method of POST /employees:batch. Gin cannot route
+// a literal colon, so the route is registered as /employees:action and the
+// parameter holds the rest of the path segment, colon included.
+const batchAction = ":batch"
+
+// EmployeeBatchRequest is the body of POST /employees:batch
+type EmployeeBatchRequest struct {
+	// Mode defaults to atomic
+	Mode       string                   `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Operations []EmployeeBatchOperation `json:"operations" validate:"required,min=1,max=1000"`
+}
+
+// EmployeeBatchOperation creates, updates or deletes one employee
+type EmployeeBatchOperation struct {
+	Op string `json:"op" validate:"required,oneof=create update delete"`
+	// ID names the employee to update or delete
+	ID uint `json:"id" validate:"required_unless=Op create"`
+	// Version makes an update or delete conditional, like If-Match; zero applies it unconditionally
+	Version uint `json:"version"`
+	// Employee is the employee to create or the full replacement of an update
+	Employee *models.Employee `json:"employee" validate:"required_unless=Op delete"`
+}
+
+// EmployeeBatchResult is the outcome of one operation, at the operation's index
+type EmployeeBatchResult struct {
+	Index         int                       `json:"index"`
+	Status        int                       `json:"status"`
+	Employee      *models.Employee          `json:"employee,omitempty"`
+	Error         string                    `json:"error,omitempty"`
+	Code          string                    `json:"code,omitempty"`
+	InvalidParams []middleware.InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// EmployeeBatchResponse lists the outcome of every operation in request order
+type EmployeeBatchResponse struct {
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []EmployeeBatchResult `json:"results"`
+}
+
+// batchOperationError is the failure that aborted an atomic batch
+type batchOperationError struct {
+	index int
+	err   error
+}
+
+func (e *batchOperationError) Error() string {
+	return fmt.Sprintf("operations[%d]: %v", e.index, e.err)
+}
+
+func (e *batchOperationError) Unwrap() error {
+	return e.err
+}
+
+// batchFailure describes a failed operation the way the single-employee
+// endpoints would respond to it
+func batchFailure(index int, op string, err error) EmployeeBatchResult {
+	result := EmployeeBatchResult{Index: index, Error: err.Error(), InvalidParams: invalidParams(err)}
+	translated := utils.TranslateDBError(err)
+	switch {
+	case result.InvalidParams != nil:
+		result.Status = http.StatusBadRequest
+		result.Code = middleware.CodeValidationFailed
+	case errors.Is(err, repository.ErrVersionConflict):
+		result.Status = http.StatusConflict
+		result.Error = "Employee has been modified since the given version"
+		result.Code = middleware.CodeVersionConflict
+	default:
+		result.Status = translated.Status
+		result.Code = translated.Code
+		switch translated.Code {
+		case utils.ErrCodeNotFound:
+			result.Error = "Employee not found"
+		case utils.ErrCodeInternal:
+			result.Error = "Failed to " + op + " employee"
+		default:
+			result.Error = translated.Message
+		}
+	}
+	return result
+}
+
+// applyBatch runs the operations that have no result yet, in order, and
+// records their outcomes. Consecutive creates are inserted together. Updates
+// keep the stored values of the hidden fields. An atomic batch stops at the
+// first failure and returns it.
+func applyBatch(ctx context.Context, repo repository.EmployeeRepository, ops []EmployeeBatchOperation, results []EmployeeBatchResult,
+	hidden []string, atomic bool) error {
+	fail := func(index int, err error) error {
+		if atomic {
+			return &batchOperationError{index: index, err: err}
+		}
+		results[index] = batchFailure(index, ops[index].Op, err)
+		return nil
+	}
+
+	for i := 0; i < len(ops); i++ {
+		if results[i].Status != 0 {
+			continue
+		}
+		op := ops[i]
+		switch op.Op {
+		case BatchOpCreate:
+			end := i + 1
+			for end < len(ops) && ops[end].Op == BatchOpCreate && results[end].Status == 0 {
+				end++
+			}
+			if err := createBatch(ctx, repo, ops, results, i, end, fail); err != nil {
+				return err
+			}
+			i = end - 1
+		case BatchOpUpdate:
+			version, err := keepStoredFields(ctx, repo, op, hidden)
+			if err != nil {
+				if err := fail(i, err); err != nil {
+					return err
+				}
+				continue
+			}
+			employee, err := repo.Update(ctx, op.ID, op.Employee, version)
+			if err != nil {
+				if err := fail(i, err); err != nil {
+					return err
+				}
+				continue
+			}
+			results[i] = EmployeeBatchResult{Index: i, Status: http.StatusOK, Employee: employee}
+		case BatchOpDelete:
+			if err := repo.Delete(ctx, op.ID, op.Version); err != nil {
+				if err := fail(i, err); err != nil {
+					return err
+				}
+				continue
+			}
+			results[i] = EmployeeBatchResult{Index: i, Status: http.StatusNoContent}
+		}
+	}
+	return nil
+}
+
+// keepStoredFields copies the stored values of the given fields into an update
+// and returns the version it must find: the stored one, when the operation
+// names none, so the copied values cannot be stale
+func keepStoredFields(ctx context.Context, repo repository.EmployeeRepository, op EmployeeBatchOperation, fields []string) (uint, error) {
+	if len(fields) == 0 {
+		return op.Version, nil
+	}
+	current, err := repo.Get(ctx, op.ID, false)
+	if err != nil {
+		return 0, err
+	}
+	if err := keepFields(fields, current, op.Employee); err != nil {
+		return 0, err
+	}
+	if op.Version == 0 {
+		return current.Version, nil
+	}
+	return op.Version, nil
+}
+
+// createBatch inserts the employees of the create operations from start to
+// end. A failed insert rolls back all of them, so outside atomic batches they
+// are retried one by one to tell which failed.
+func createBatch(ctx context.Context, repo repository.EmployeeRepository, ops []EmployeeBatchOperation, results []EmployeeBatchResult,
+	start, end int, fail func(index int, err error) error) error {
+	employees := make([]*models.Employee, 0, end-start)
+	for i := start; i < end; i++ {
+		employees = append(employees, newEmployee(ops[i].Employee))
+	}
+
+	err := repo.CreateBatch(ctx, employees)
+	if err == nil {
+		for i, employee := range employees {
+			results[start+i] = EmployeeBatchResult{Index: start + i, Status: http.StatusCreated, Employee: employee}
+		}
+		return nil
+	}
+	// An atomic batch fails as a whole, reported at the first create of the run
+	if err := fail(start, err); err != nil {
+		return err
+	}
+
+	for i := start; i < end; i++ {
+		employee := newEmployee(ops[i].Employee)
+		if err := repo.Create(ctx, employee); err != nil {
+			_ = fail(i, err)
+			continue
+		}
+		results[i] = EmployeeBatchResult{Index: i, Status: http.StatusCreated, Employee: employee}
+	}
+	return nil
+}
+
+// newEmployee copies a requested employee without the fields the repository
+// assigns, so a failed insert can be retried from the request
+func newEmployee(requested *models.Employee) *models.Employee {
+	employee := *requested
+	employee.Base = models.Base{}
+	employee.Version = 0
+	return &employee
+}
+
+// Batch handles POST /employees:batch, which creates, updates and deletes up
+// to 1000 employees in one request. An atomic batch runs in one transaction
+// and fails as a whole with the error of the first failed operation; a
+// best-effort batch applies what it can and answers 207 Multi-Status with the
+// outcome of every operation.
+func (h *EmployeeHandler) Batch(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	if c.Param("action") != batchAction {
+		NotFound(c)
+		return
+	}
+
+	var request EmployeeBatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.LogValidationError(c, "employee_batch", nil, err, logrus.Fields{
+			"operation": "batch_employees",
+		})
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
+		})
+		return
+	}
+	if err := validation.Struct(&request); err != nil {
+		utils.LogValidationError(c, "employee_batch", len(request.Operations), err, logrus.Fields{
+			"operation": "batch_employees",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	if request.Mode == "" {
+		request.Mode = BatchModeAtomic
+	}
+	atomic := request.Mode == BatchModeAtomic
+	ops := request.Operations
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "batch_employees",
+		"mode":       request.Mode,
+		"operations": len(ops),
+	}).Info("Processing employee batch request")
+
+	// Deleting is a permission of its own, not implied by the route's employees:write permission
+	permissions := middleware.GetPermissions(c)
+	for _, op := range ops {
+		if op.Op == BatchOpDelete && permissions != nil && !permissions.Has(auth.PermEmployeesDelete) {
+			utils.LogBusinessError(c, "batch_employees", errors.New("batch deletes employees without permission"))
+			middleware.RespondError(c, http.StatusForbidden, middleware.ErrorResponse{
+				Error: "Permission " + string(auth.PermEmployeesDelete) + " required",
+				Code:  middleware.CodeForbidden,
+			})
+			return
+		}
+	}
+
+	// Like PUT, updates may not change fields the caller cannot see
+	for i, op := range ops {
+		if op.Op != BatchOpUpdate || op.Employee == nil {
+			continue
+		}
+		set, err := hiddenFieldsSet(c, op.Employee)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if len(set) > 0 {
+			utils.LogBusinessError(c, "batch_employees", errors.New("batch update sets hidden fields"), logrus.Fields{
+				"operation_index": i,
+				"fields":          set,
+			})
+			middleware.RespondHiddenFieldsWritten(c, fmt.Sprintf("operations[%d].employee.", i), set)
+			return
+		}
+	}
+	hidden := c.GetStringSlice(middleware.HiddenFieldsKey)
+
+	// Invalid operations fail an atomic batch before anything is written and
+	// are skipped by a best-effort one
+	results := make([]EmployeeBatchResult, len(ops))
+	var invalid validation.Errors
+	for i := range ops {
+		err := validation.Prefix(fmt.Sprintf("operations[%d]", i), validation.Struct(&ops[i]))
+		if err == nil {
+			continue
+		}
+		results[i] = batchFailure(i, ops[i].Op, err)
+		var fieldErrs validation.Errors
+		if errors.As(err, &fieldErrs) {
+			invalid = append(invalid, fieldErrs...)
+		}
+	}
+
+	status := http.StatusMultiStatus
+	if atomic {
+		if len(invalid) > 0 {
+			utils.LogValidationError(c, "employee_batch", len(ops), invalid, logrus.Fields{
+				"operation": "batch_employees",
+			})
+			respondValidationError(c, invalid)
+			return
+		}
+		err := h.repo.Transaction(c.Request.Context(), func(repo repository.EmployeeRepository) error {
+			return applyBatch(c.Request.Context(), repo, ops, results, hidden, true)
+		})
+		var opErr *batchOperationError
+		if errors.As(err, &opErr) {
+			respondBatchFailure(c, ops, opErr)
+			return
+		}
+		if err != nil {
+			respondDBError(c, "batch_employees", err, "", "Failed to apply employee batch")
+			return
+		}
+		status = http.StatusOK
+	} else if err := applyBatch(c.Request.Context(), h.repo, ops, results, hidden, false); err != nil {
+		respondDBError(c, "batch_employees", err, "", "Failed to apply employee batch")
+		return
+	}
+
+	response := EmployeeBatchResponse{Results: results}
+	for _, result := range results {
+		if result.Status < http.StatusBadRequest {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+
+	// Log batch outcome
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "batch_employees",
+		"mode":       request.Mode,
+		"succeeded":  response.Succeeded,
+		"failed":     response.Failed,
+	}).Info("Employee batch processed")
+
+	middleware.RestrictedJSON(c, status, response)
+}
+
+// respondBatchFailure writes the response for an atomic batch rolled back by
+// one failed operation, named in the message and invalid-params
+func respondBatchFailure(c *gin.Context, ops []EmployeeBatchOperation, opErr *batchOperationError) {
+	result := batchFailure(opErr.index, ops[opErr.index].Op, opErr.err)
+	utils.LogDBError(c, "batch_employees", opErr.err, logrus.Fields{
+		"operation_index": opErr.index,
+		"employee_id":     ops[opErr.index].ID,
+	})
+	if utils.TranslateDBError(opErr.err).Retryable {
+		c.Header("Retry-After", "1")
+	}
+
+	name := fmt.Sprintf("operations[%d]", opErr.index)
+	middleware.RespondError(c, result.Status, middleware.ErrorResponse{
+		Error:         name + ": " + result.Error,
+		Code:          result.Code,
+		InvalidParams: []middleware.InvalidParam{{Name: name, Reason: result.Error}},
+	})
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/auth"
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+// batchStatuses returns the status of every operation of a batch response
+func batchStatuses(t *testing.T, body []byte) (EmployeeBatchResponse, []int) {
+	t.Helper()
+	var response EmployeeBatchResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	statuses := make([]int, len(response.Results))
+	for i, result := range response.Results {
+		assert.Equal(t, i, result.Index)
+		statuses[i] = result.Status
+	}
+	return response, statuses
+}
+
+// countEmployees returns how many employees are not deleted
+func countEmployees(t *testing.T, repo repository.EmployeeRepository) int64 {
+	t.Helper()
+	list, err := repo.List(context.Background(), repository.EmployeeListOptions{
+		Sort:  []repository.SortField{{Column: "id"}},
+		Limit: 100,
+	})
+	require.NoError(t, err)
+	return list.Total
+}
+
+func TestEmployeeBatch_Atomic(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seeded := seedEmployees(t, repo, [2]string{"Ada", "Lovelace"}, [2]string{"Alan", "Turing"})
+
+	body := fmt.Sprintf(`{"operations": [
+		{"op": "create", "employee": {"first_name": "Grace", "last_name": "Hopper"}},
+		{"op": "create", "employee": {"first_name": "Edsger", "last_name": "Dijkstra"}},
+		{"op": "update", "id": %d, "version": 1, "employee": {"first_name": "Ada", "last_name": "King"}},
+		{"op": "delete", "id": %d}
+	]}`, seeded[0].ID, seeded[1].ID)
+	w := performRequest(router, http.MethodPost, "/employees:batch", []byte(body))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	response, statuses := batchStatuses(t, w.Body.Bytes())
+	assert.Equal(t, []int{http.StatusCreated, http.StatusCreated, http.StatusOK, http.StatusNoContent}, statuses)
+	assert.Equal(t, 4, response.Succeeded)
+	assert.Equal(t, 0, response.Failed)
+	assert.Equal(t, "Grace", response.Results[0].Employee.FirstName)
+	assert.NotZero(t, response.Results[1].Employee.ID)
+	assert.Equal(t, uint(2), response.Results[2].Employee.Version)
+
+	updated, err := repo.Get(context.Background(), seeded[0].ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, "King", updated.LastName)
+	_, err = repo.Get(context.Background(), seeded[1].ID, false)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestEmployeeBatch_AtomicRollback(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seeded := seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	body := fmt.Sprintf(`{"mode": "atomic", "operations": [
+		{"op": "create", "employee": {"first_name": "Grace", "last_name": "Hopper"}},
+		{"op": "update", "id": %d, "employee": {"first_name": "Ada", "last_name": "King"}},
+		{"op": "delete", "id": 999}
+	]}`, seeded[0].ID)
+	w := performRequest(router, http.MethodPost, "/employees:batch", []byte(body))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"operations[2]: Employee not found","code":"not_found"}`, w.Body.String())
+
+	// Nothing of the batch was kept
+	assert.Equal(t, int64(1), countEmployees(t, repo))
+	current, err := repo.Get(context.Background(), seeded[0].ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Lovelace", current.LastName)
+	assert.Equal(t, uint(1), current.Version)
+}
+
+func TestEmployeeBatch_AtomicValidation(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+
+	body := `{"operations": [
+		{"op": "create", "employee": {"first_name": "Grace", "last_name": "Hopper"}},
+		{"op": "create", "employee": {"first_name": "R2D2"}},
+		{"op": "update", "employee": {"first_name": "Ada", "last_name": "King"}},
+		{"op": "upsert"}
+	]}`
+	w := performConditional(router, http.MethodPost, "/employees:batch", "Accept", middleware.ProblemContentType, "application/json", body)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var problem middleware.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, middleware.CodeValidationFailed, problem.Code)
+	assert.Equal(t, []middleware.InvalidParam{
+		{Name: "operations[1].employee.first_name", Reason: "must contain only letters, spaces, hyphens, apostrophes and periods"},
+		{Name: "operations[1].employee.last_name", Reason: "is required"},
+		{Name: "operations[2].id", Reason: "is required"},
+		{Name: "operations[3].op", Reason: "must be one of: create, update, delete"},
+		{Name: "operations[3].id", Reason: "is required"},
+		{Name: "operations[3].employee", Reason: "is required"},
+	}, problem.InvalidParams)
+	assert.Equal(t, int64(0), countEmployees(t, repo))
+}
+
+func TestEmployeeBatch_BestEffort(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seeded := seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	body := fmt.Sprintf(`{"mode": "best_effort", "operations": [
+		{"op": "create", "employee": {"first_name": "Grace", "last_name": "Hopper"}},
+		{"op": "create", "employee": {"first_name": "", "last_name": "Hopper"}},
+		{"op": "update", "id": %d, "version": 7, "employee": {"first_name": "Ada", "last_name": "King"}},
+		{"op": "delete", "id": 999},
+		{"op": "create", "employee": {"first_name": "Alan", "last_name": "Turing"}}
+	]}`, seeded[0].ID)
+	w := performRequest(router, http.MethodPost, "/employees:batch", []byte(body))
+
+	require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+	response, statuses := batchStatuses(t, w.Body.Bytes())
+	assert.Equal(t, []int{
+		http.StatusCreated, http.StatusBadRequest, http.StatusConflict, http.StatusNotFound, http.StatusCreated,
+	}, statuses)
+	assert.Equal(t, 2, response.Succeeded)
+	assert.Equal(t, 3, response.Failed)
+
+	assert.Equal(t, "validation_failed", response.Results[1].Code)
+	assert.Equal(t, "operations[1].employee.first_name is required", response.Results[1].Error)
+	assert.Equal(t, "version_conflict", response.Results[2].Code)
+	assert.Equal(t, "not_found", response.Results[3].Code)
+	assert.Nil(t, response.Results[3].Employee)
+
+	assert.Equal(t, int64(3), countEmployees(t, repo))
+}
+
+// batchFailingRepository fails every batch insert
+type batchFailingRepository struct {
+	*repository.MemoryEmployeeRepository
+}
+
+func (r *batchFailingRepository) CreateBatch(context.Context, []*models.Employee) error {
+	return errors.New("connection reset")
+}
+
+func TestEmployeeBatch_BestEffortRetriesFailedInserts(t *testing.T) {
+	repo := &batchFailingRepository{repository.NewMemoryEmployeeRepository()}
+	router := setupTestRouter()
+	router.POST("/employees:action", NewEmployeeHandler(repo).Batch)
+
+	body := `{"mode": "best_effort", "operations": [
+		{"op": "create", "employee": {"first_name": "Grace", "last_name": "Hopper"}},
+		{"op": "create", "employee": {"first_name": "Alan", "last_name": "Turing"}}
+	]}`
+	w := performRequest(router, http.MethodPost, "/employees:batch", []byte(body))
+
+	require.Equal(t, http.StatusMultiStatus, w.Code)
+	_, statuses := batchStatuses(t, w.Body.Bytes())
+	assert.Equal(t, []int{http.StatusCreated, http.StatusCreated}, statuses)
+	assert.Equal(t, int64(2), countEmployees(t, repo))
+}
+
+func TestEmployeeBatch_Request(t *testing.T) {
+	router, _ := setupEmployeeRouter()
+
+	tooMany := `{"operations": [` + strings.Repeat(`{"op": "delete", "id": 1},`, 1000) + `{"op": "delete", "id": 1}]}`
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		want   string
+	}{
+		{
+			name: "no operations", path: "/employees:batch", body: `{"operations": []}`,
+			status: http.StatusBadRequest, want: `{"error":"operations must have at least 1 item","code":"validation_failed"}`,
+		},
+		{
+			name: "too many operations", path: "/employees:batch", body: tooMany,
+			status: http.StatusBadRequest, want: `{"error":"operations must have at most 1000 items","code":"validation_failed"}`,
+		},
+		{
+			name: "unknown mode", path: "/employees:batch", body: `{"mode": "eventually", "operations": [{"op": "delete", "id": 1}]}`,
+			status: http.StatusBadRequest, want: `{"error":"mode must be one of: atomic, best_effort","code":"validation_failed"}`,
+		},
+		{
+			name: "malformed body", path: "/employees:batch", body: `{"operations": {}}`,
+			status: http.StatusBadRequest, want: `{"error":"Invalid request format","code":"invalid_request"}`,
+		},
+		{
+			name: "unknown action", path: "/employees:merge", body: `{"operations": [{"op": "delete", "id": 1}]}`,
+			status: http.StatusNotFound, want: `{"error":"Resource not found","code":"not_found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodPost, tt.path, []byte(tt.body))
+			assert.Equal(t, tt.status, w.Code)
+			assert.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestEmployeeBatch_DeleteRequiresPermission(t *testing.T) {
+	repo := repository.NewMemoryEmployeeRepository()
+	seeded := seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+	router := setupTestRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.PermissionsKey, auth.PermissionSet{auth.PermEmployeesWrite: true})
+		c.Next()
+	})
+	router.POST("/employees:action", NewEmployeeHandler(repo).Batch)
+
+	body := fmt.Sprintf(`{"operations": [{"op": "delete", "id": %d}]}`, seeded[0].ID)
+	w := performRequest(router, http.MethodPost, "/employees:batch", []byte(body))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"error":"Permission employees:delete required","code":"forbidden"}`, w.Body.String())
+	assert.Equal(t, int64(1), countEmployees(t, repo))
+
+	// Problem details name the missing permission too
+	w = performConditional(router, http.MethodPost, "/employees:batch", "Accept", middleware.ProblemContentType, "application/json", body)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var problem middleware.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, middleware.CodeForbidden, problem.Code)
+	assert.Equal(t, "Permission employees:delete required", problem.Detail)
+}
+
+func TestEmployeeBatch_HiddenFields(t *testing.T) {
+	repo := repository.NewMemoryEmployeeRepository()
+	salary := 85000.0
+	require.NoError(t, repo.Create(context.Background(), &models.Employee{FirstName: "Ada", LastName: "Lovelace", Salary: &salary}))
+	router := setupAuthorizedEmployeeRouter(repo, editorPolicy(), "editor")
+
+	// Updates may not set the hidden salary
+	body := `{"operations": [{"op": "update", "id": 1, "employee": {"first_name": "Ada", "last_name": "King", "salary": 1}}]}`
+	w := performConditional(router, http.MethodPost, "/employees:batch", "Accept", middleware.ProblemContentType, "application/json", body)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var problem middleware.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "Permission employees:read_sensitive required", problem.Detail)
+	assert.Equal(t, []middleware.InvalidParam{{Name: "operations[0].employee.salary", Reason: "requires permission employees:read_sensitive"}}, problem.InvalidParams)
+
+	// Other changes apply and the stored salary is kept
+	body = `{"operations": [{"op": "update", "id": 1, "employee": {"first_name": "Ada", "last_name": "King"}}]}`
+	w = performRequest(router, http.MethodPost, "/employees:batch", []byte(body))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "salary")
+
+	employee, err := repo.Get(context.Background(), 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "King", employee.LastName)
+	require.NotNil(t, employee.Salary)
+	assert.Equal(t, salary, *employee.Salary)
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/utils"
+)
+
+// Formats of an employee export
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// NDJSONContentType is the media type of newline-delimited JSON
+const NDJSONContentType = "application/x-ndjson"
+
+// exportFlushRows is how many rows are written between flushes, so clients
+// see the export progress while it is read from the database
+const exportFlushRows = 1000
+
+// Trailers sent after the body of an export, since a failure after the first
+// rows can no longer change the status code
+const (
+	ExportRowsTrailer  = "X-Export-Rows"
+	ExportErrorTrailer = "X-Export-Error"
+)
+
+// exportContentTypes maps export formats to their media types
+var exportContentTypes = map[string]string{
+	ExportFormatCSV:    "text/csv",
+	ExportFormatNDJSON: NDJSONContentType,
+}
+
+// exportColumn is a field of exported employees, by JSON name
+type exportColumn struct {
+	name  string
+	value func(employee *models.Employee) interface{}
+}
+
+// employeeExportColumns are the exported fields in output order
+var employeeExportColumns = []exportColumn{
+	{"id", func(e *models.Employee) interface{} { return e.ID }},
+	{"employee_number", func(e *models.Employee) interface{} { return e.EmployeeNumber }},
+	{"first_name", func(e *models.Employee) interface{} { return e.FirstName }},
+	{"last_name", func(e *models.Employee) interface{} { return e.LastName }},
+	{"department_id", func(e *models.Employee) interface{} { return e.DepartmentID }},
+	{"salary", func(e *models.Employee) interface{} { return e.Salary }},
+	{"version", func(e *models.Employee) interface{} { return e.Version }},
+	{"created_at", func(e *models.Employee) interface{} { return e.CreatedAt }},
+	{"updated_at", func(e *models.Employee) interface{} { return e.UpdatedAt }},
+	{"deleted_at", func(e *models.Employee) interface{} { return e.DeletedAt }},
+}
+
+// exportFormat picks the format of an export from the format query parameter
+// or else the Accept header; "" means the client accepts no export format
+func exportFormat(c *gin.Context) (string, error) {
+	if format := c.Query("format"); format != "" {
+		if _, ok := exportContentTypes[format]; !ok {
+			return "", fmt.Errorf("format must be one of: %s, %s", ExportFormatCSV, ExportFormatNDJSON)
+		}
+		return format, nil
+	}
+	switch c.NegotiateFormat(exportContentTypes[ExportFormatCSV], NDJSONContentType, "application/ndjson") {
+	case exportContentTypes[ExportFormatCSV]:
+		return ExportFormatCSV, nil
+	case NDJSONContentType, "application/ndjson":
+		return ExportFormatNDJSON, nil
+	default:
+		return "", nil
+	}
+}
+
+// csvValue formats an exported value as a CSV cell
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case *uint:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(*v), 10)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case gorm.DeletedAt:
+		if !v.Valid {
+			return ""
+		}
+		return v.Time.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// exportWriter writes exported employees in one format
+type exportWriter interface {
+	WriteHeader(columns []exportColumn) error
+	WriteEmployee(columns []exportColumn, employee *models.Employee) error
+	Flush() error
+}
+
+// csvExportWriter writes a header line and one line per employee
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvExportWriter) WriteHeader(columns []exportColumn) error {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+	return w.w.Write(names)
+}
+
+func (w *csvExportWriter) WriteEmployee(columns []exportColumn, employee *models.Employee) error {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = csvValue(column.value(employee))
+	}
+	return w.w.Write(record)
+}
+
+func (w *csvExportWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// ndjsonExportWriter writes one JSON object per line, fields in column order
+type ndjsonExportWriter struct {
+	w *bufio.Writer
+}
+
+func (w *ndjsonExportWriter) WriteHeader([]exportColumn) error {
+	return nil
+}
+
+func (w *ndjsonExportWriter) WriteEmployee(columns []exportColumn, employee *models.Employee) error {
+	w.w.WriteByte('{')
+	for i, column := range columns {
+		value, err := json.Marshal(column.value(employee))
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			w.w.WriteByte(',')
+		}
+		w.w.WriteString(strconv.Quote(column.name))
+		w.w.WriteByte(':')
+		w.w.Write(value)
+	}
+	_, err := w.w.WriteString("}\n")
+	return err
+}
+
+func (w *ndjsonExportWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// newExportWriter creates the writer of a format on top of the response body
+func newExportWriter(format string, w io.Writer) exportWriter {
+	if format == ExportFormatNDJSON {
+		return &ndjsonExportWriter{w: bufio.NewWriter(w)}
+	}
+	return &csvExportWriter{w: csv.NewWriter(w)}
+}
+
+// Export handles GET /employees/export, which streams every employee matching
+// the list filters as CSV or NDJSON. Rows are read from a database cursor and
+// flushed as they are written, so memory use does not grow with the number of
+// employees. Errors before anything was sent get a regular error response;
+// later ones end the body early and put their code in the X-Export-Error trailer.
+func (h *EmployeeHandler) Export(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	format, err := exportFormat(c)
+	if err != nil {
+		utils.LogValidationError(c, "format", c.Query("format"), err, logrus.Fields{
+			"operation": "export_employees",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	if format == "" {
+		utils.LogValidationError(c, "accept", c.GetHeader("Accept"), errors.New("no acceptable export format"), logrus.Fields{
+			"operation": "export_employees",
+		})
+		middleware.RespondError(c, http.StatusNotAcceptable, middleware.ErrorResponse{
+			Error: "Accept must allow text/csv or " + NDJSONContentType,
+			Code:  middleware.CodeNotAcceptable,
+		})
+		return
+	}
+
+	filter, err := parseEmployeeFilter(c)
+	if err != nil {
+		utils.LogValidationError(c, "query", c.Request.URL.RawQuery, err, logrus.Fields{
+			"operation": "export_employees",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	sort, err := parseSort(c.Query("sort"))
+	if err != nil {
+		utils.LogValidationError(c, "sort", c.Query("sort"), err, logrus.Fields{
+			"operation": "export_employees",
+		})
+		respondValidationError(c, err)
+		return
+	}
+
+	// Hidden fields are left out of the export like they are of responses
+	hidden := c.GetStringSlice(middleware.HiddenFieldsKey)
+	columns := make([]exportColumn, 0, len(employeeExportColumns))
+	for _, column := range employeeExportColumns {
+		if !slices.Contains(hidden, column.name) {
+			columns = append(columns, column)
+		}
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "export_employees",
+		"format":     format,
+	}).Info("Processing export employees request")
+
+	// The response starts with the first row, so that a failed query still
+	// gets an error status
+	writer := newExportWriter(format, c.Writer)
+	rows := 0
+	start := func() error {
+		filename := fmt.Sprintf("employees-%s.%s", time.Now().UTC().Format("20060102"), format)
+		c.Header("Content-Type", exportContentTypes[format]+"; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Header("Trailer", ExportRowsTrailer+", "+ExportErrorTrailer)
+		c.Status(http.StatusOK)
+		return writer.WriteHeader(columns)
+	}
+	err = h.repo.Stream(c.Request.Context(), filter, sort, func(employee *models.Employee) error {
+		if rows == 0 {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.WriteEmployee(columns, employee); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil && rows == 0 {
+		err = start()
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	// Nothing has been sent while the rows fit in the writer's buffer
+	if err != nil && !c.Writer.Written() {
+		for _, name := range []string{"Content-Type", "Content-Disposition", "Trailer"} {
+			c.Writer.Header().Del(name)
+		}
+		respondDBError(c, "export_employees", err, "", "Failed to export employees")
+		return
+	}
+	c.Writer.Header().Set(ExportRowsTrailer, strconv.Itoa(rows))
+	if err != nil {
+		// Send the complete rows still buffered, so the body matches the row count
+		_ = writer.Flush()
+		utils.LogDBError(c, "export_employees", err, logrus.Fields{
+			"rows": rows,
+		})
+		c.Writer.Header().Set(ExportErrorTrailer, utils.TranslateDBError(err).Code)
+		return
+	}
+
+	// Log successful export
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "export_employees",
+		"format":     format,
+		"rows":       rows,
+	}).Info("Employees exported successfully")
+}
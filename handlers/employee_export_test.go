@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+func TestEmployeeExport_CSV(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seeded := seedEmployees(t, repo, [2]string{"Ada", "Lovelace"}, [2]string{"Alan", "Turing"}, [2]string{"Grace", "Hopper"})
+	require.NoError(t, repo.Delete(context.Background(), seeded[1].ID, 0))
+
+	w := performRequest(router, http.MethodGet, "/employees/export?sort=-first_name", nil)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="employees-\d{8}\.csv"$`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "2", w.Result().Trailer.Get(ExportRowsTrailer))
+	assert.Empty(t, w.Result().Trailer.Get(ExportErrorTrailer))
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "employee_number", "first_name", "last_name", "department_id", "salary", "version", "created_at", "updated_at", "deleted_at"}, records[0])
+	assert.Equal(t, []string{"3", "", "Grace", "Hopper", "", "", "1"}, records[1][:7])
+	assert.Equal(t, "Ada", records[2][2])
+	assert.Empty(t, records[2][9])
+}
+
+func TestEmployeeExport_NDJSON(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seeded := seedEmployees(t, repo, [2]string{"Ada", "Lovelace"}, [2]string{"Alan", "Turing"}, [2]string{"Ada", "King"})
+	require.NoError(t, repo.Delete(context.Background(), seeded[2].ID, 0))
+
+	tests := []struct {
+		name, path, accept string
+	}{
+		{name: "accept", path: "/employees/export?first_name=ada&include_deleted=true", accept: NDJSONContentType},
+		{name: "format parameter", path: "/employees/export?format=ndjson&first_name=ada&include_deleted=true", accept: "text/csv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performConditional(router, http.MethodGet, tt.path, "Accept", tt.accept, "", "")
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, NDJSONContentType+"; charset=utf-8", w.Header().Get("Content-Type"))
+			lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+			require.Len(t, lines, 2)
+			assert.True(t, strings.HasPrefix(lines[0], `{"id":1,"employee_number":"","first_name":"Ada","last_name":"Lovelace","department_id":null,"salary":null,"version":1,`), lines[0])
+
+			var deleted models.Employee
+			require.NoError(t, json.Unmarshal([]byte(lines[1]), &deleted))
+			assert.Equal(t, "King", deleted.LastName)
+			assert.True(t, deleted.DeletedAt.Valid)
+		})
+	}
+}
+
+func TestEmployeeExport_HidesRestrictedFields(t *testing.T) {
+	repo := repository.NewMemoryEmployeeRepository()
+	seedNumberedEmployees(t, repo, [3]string{"E1", "Ada", "Lovelace"})
+	router := setupTestRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.HiddenFieldsKey, []string{"employee_number"})
+		c.Next()
+	})
+	router.GET("/employees/export", NewEmployeeHandler(repo).Export)
+
+	w := performRequest(router, http.MethodGet, "/employees/export?format=ndjson", nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "employee_number")
+	assert.NotContains(t, w.Body.String(), "E1")
+}
+
+func TestEmployeeExport_Request(t *testing.T) {
+	router, _ := setupEmployeeRouter()
+
+	tests := []struct {
+		name   string
+		path   string
+		accept string
+		status int
+		want   string
+	}{
+		{
+			name: "unknown format", path: "/employees/export?format=xml",
+			status: http.StatusBadRequest, want: `{"error":"format must be one of: csv, ndjson","code":"validation_failed"}`,
+		},
+		{
+			name: "unacceptable", path: "/employees/export", accept: "application/xml",
+			status: http.StatusNotAcceptable, want: `{"error":"Accept must allow text/csv or application/x-ndjson","code":"not_acceptable"}`,
+		},
+		{
+			name: "invalid filter", path: "/employees/export?created_after=yesterday",
+			status: http.StatusBadRequest, want: `{"error":"created_after must be an RFC 3339 timestamp or a YYYY-MM-DD date","code":"validation_failed"}`,
+		},
+		{
+			name: "invalid sort", path: "/employees/export?sort=salary",
+			status: http.StatusBadRequest, want: `{"error":"invalid sort field: salary","code":"validation_failed"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performConditional(router, http.MethodGet, tt.path, "Accept", tt.accept, "", "")
+			assert.Equal(t, tt.status, w.Code)
+			assert.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+// streamFailingRepository streams some employees and then fails
+type streamFailingRepository struct {
+	*repository.MemoryEmployeeRepository
+	rows int
+}
+
+func (r *streamFailingRepository) Stream(_ context.Context, _ repository.EmployeeFilter, _ []repository.SortField, fn func(employee *models.Employee) error) error {
+	for i := 1; i <= r.rows; i++ {
+		if err := fn(&models.Employee{Base: models.Base{ID: uint(i)}, FirstName: "Ada", LastName: "Lovelace"}); err != nil {
+			return err
+		}
+	}
+	return errors.New("connection reset")
+}
+
+func TestEmployeeExport_Failure(t *testing.T) {
+	export := func(rows int) (*http.Response, string) {
+		repo := &streamFailingRepository{MemoryEmployeeRepository: repository.NewMemoryEmployeeRepository(), rows: rows}
+		router := setupTestRouter()
+		router.GET("/employees/export", NewEmployeeHandler(repo).Export)
+		w := performRequest(router, http.MethodGet, "/employees/export", nil)
+		return w.Result(), w.Body.String()
+	}
+
+	// Rows still in the buffer are discarded for an error response
+	response, body := export(10)
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.JSONEq(t, `{"error":"Failed to export employees","code":"internal_error"}`, body)
+	assert.Empty(t, response.Header.Get("Content-Disposition"))
+
+	// Once rows were sent the export ends early and says so in its trailers
+	response, body = export(exportFlushRows + 5)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "1005", response.Trailer.Get(ExportRowsTrailer))
+	assert.Equal(t, "internal_error", response.Trailer.Get(ExportErrorTrailer))
+	lines := 0
+	for scanner := bufio.NewScanner(strings.NewReader(body)); scanner.Scan(); {
+		lines++
+	}
+	assert.Equal(t, exportFlushRows+6, lines)
+}
@@ -0,0 +1,583 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/spreadsheet"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// maxImportSize bounds the uploaded file, multipart framing included
+const maxImportSize = 10 << 20
+
+// maxImportRows bounds the employee rows of one import, the header not counted
+const maxImportRows = 10000
+
+// Actions of an import row
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+	ImportActionInvalid   = "invalid"
+)
+
+// employeeImportFields are the employee fields a column can map to, by JSON name
+var employeeImportFields = map[string]func(employee *models.Employee) *string{
+	"employee_number": func(employee *models.Employee) *string { return &employee.EmployeeNumber },
+	"first_name":      func(employee *models.Employee) *string { return &employee.FirstName },
+	"last_name":       func(employee *models.Employee) *string { return &employee.LastName },
+}
+
+// employeeImportAliases map common spreadsheet headers to employee fields
+var employeeImportAliases = map[string]string{
+	"employee_no": "employee_number",
+	"firstname":   "first_name",
+	"given_name":  "first_name",
+	"forename":    "first_name",
+	"lastname":    "last_name",
+	"surname":     "last_name",
+	"family_name": "last_name",
+}
+
+// EmployeeImportReport describes what an import did, or would do in a dry run
+type EmployeeImportReport struct {
+	DryRun bool `json:"dry_run"`
+	// Applied tells whether the employees were written; an import with invalid rows writes none
+	Applied bool `json:"applied"`
+	// Columns maps the headers of the file to employee fields
+	Columns        map[string]string   `json:"columns"`
+	IgnoredColumns []string            `json:"ignored_columns"`
+	Created        int                 `json:"created"`
+	Updated        int                 `json:"updated"`
+	Unchanged      int                 `json:"unchanged"`
+	Invalid        int                 `json:"invalid"`
+	Rows           []EmployeeImportRow `json:"rows"`
+}
+
+// EmployeeImportRow is the outcome of one row of the file
+type EmployeeImportRow struct {
+	// Row is the row number in the file, the header being row 1
+	Row            int                   `json:"row"`
+	Action         string                `json:"action"`
+	EmployeeID     uint                  `json:"employee_id,omitempty"`
+	EmployeeNumber string                `json:"employee_number,omitempty"`
+	Errors         []EmployeeImportError `json:"errors,omitempty"`
+}
+
+// EmployeeImportError is an invalid value of a row
+type EmployeeImportError struct {
+	Field string `json:"field"`
+	// Column is the header of the column the value came from, if any
+	Column string `json:"column,omitempty"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// employeeImport is a parsed import file and the plan to apply it
+type employeeImport struct {
+	header []string
+	// columns holds the field of every column of the header, "" if ignored
+	columns []string
+	cells   [][]string
+	rows    []EmployeeImportRow
+	// employees holds the employee to create or the replacement of each row
+	employees []models.Employee
+	versions  []uint
+}
+
+// normalizeHeader turns a column header like "First Name" into first_name
+func normalizeHeader(header string) string {
+	var name strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(header)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && name.Len() > 0 {
+				name.WriteByte('_')
+			}
+			underscore = false
+			name.WriteRune(r)
+			continue
+		}
+		underscore = true
+	}
+	return name.String()
+}
+
+// importFieldNames lists the fields columns can map to, for error messages
+func importFieldNames() string {
+	names := make([]string, 0, len(employeeImportFields))
+	for name := range employeeImportFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// mapImportColumns returns the employee field of every header, or "" for
+// columns that are ignored. mapping overrides the field of a header; mapping
+// it to an empty string ignores the column.
+func mapImportColumns(header []string, mapping map[string]string) ([]string, error) {
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = normalizeHeader(name)
+		if alias, ok := employeeImportAliases[name]; ok {
+			name = alias
+		}
+		if _, ok := employeeImportFields[name]; ok {
+			columns[i] = name
+		}
+	}
+
+	var invalid validation.Errors
+	for key, field := range mapping {
+		param := "mapping[" + key + "]"
+		field = normalizeHeader(field)
+		if _, ok := employeeImportFields[field]; !ok && field != "" {
+			invalid = append(invalid, validation.FieldError{Field: param, Reason: "must be one of: " + importFieldNames()})
+			continue
+		}
+		found := false
+		for i, name := range header {
+			if normalizeHeader(name) == normalizeHeader(key) {
+				columns[i] = field
+				found = true
+			}
+		}
+		if !found {
+			invalid = append(invalid, validation.FieldError{Field: param, Reason: "must name a column of the file"})
+		}
+	}
+
+	mapped := make(map[string]string, len(employeeImportFields))
+	for i, field := range columns {
+		if field == "" {
+			continue
+		}
+		if previous, ok := mapped[field]; ok {
+			invalid = append(invalid, validation.FieldError{
+				Field:  header[i],
+				Reason: fmt.Sprintf("maps to %s like column %q", field, previous),
+			})
+			continue
+		}
+		mapped[field] = header[i]
+	}
+	if len(mapped) == 0 && len(invalid) == 0 {
+		invalid = append(invalid, validation.FieldError{Field: "file", Reason: "must have a column for one of: " + importFieldNames()})
+	}
+
+	if len(invalid) > 0 {
+		sort.Slice(invalid, func(i, j int) bool { return invalid[i].Field < invalid[j].Field })
+		return nil, invalid
+	}
+	return columns, nil
+}
+
+// cell returns a trimmed cell of a row, rows being as long as their last value
+func cell(cells []string, column int) string {
+	if column >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[column])
+}
+
+// planImport decides the action of every row. Rows whose employee number
+// belongs to a current employee update it with their non-empty cells; other
+// rows create an employee. Every row is validated like a request body.
+func (h *EmployeeHandler) planImport(c *gin.Context, imp *employeeImport) error {
+	numberColumn := -1
+	fieldColumns := make(map[string]int, len(employeeImportFields))
+	for i, field := range imp.columns {
+		if field != "" {
+			fieldColumns[field] = i
+		}
+		if field == "employee_number" {
+			numberColumn = i
+		}
+	}
+
+	var numbers []string
+	if numberColumn >= 0 {
+		for _, cells := range imp.cells {
+			if number := cell(cells, numberColumn); number != "" {
+				numbers = append(numbers, number)
+			}
+		}
+	}
+	found, err := h.repo.FindByEmployeeNumbers(c.Request.Context(), numbers)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*models.Employee, len(found))
+	for i := range found {
+		existing[found[i].EmployeeNumber] = &found[i]
+	}
+
+	imp.employees = make([]models.Employee, len(imp.cells))
+	imp.versions = make([]uint, len(imp.cells))
+	seen := make(map[string]int, len(numbers))
+	for i, cells := range imp.cells {
+		row := &imp.rows[i]
+		employee := &imp.employees[i]
+		action := ImportActionCreate
+
+		number := ""
+		if numberColumn >= 0 {
+			number = cell(cells, numberColumn)
+		}
+		if current, ok := existing[number]; ok {
+			*employee = *current
+			row.EmployeeID = current.ID
+			imp.versions[i] = current.Version
+			action = ImportActionUpdate
+		}
+		row.EmployeeNumber = number
+
+		changed := false
+		for field, column := range fieldColumns {
+			value := cell(cells, column)
+			target := employeeImportFields[field](employee)
+			// Empty cells leave the fields of existing employees as they are
+			if value == "" && action == ImportActionUpdate {
+				continue
+			}
+			if *target != value {
+				*target = value
+				changed = true
+			}
+		}
+		if action == ImportActionUpdate && !changed {
+			action = ImportActionUnchanged
+		}
+
+		if err := validation.Struct(employee); err != nil {
+			row.Errors = importErrors(err, imp.header, fieldColumns, cells)
+		}
+		if number != "" {
+			if first, ok := seen[number]; ok {
+				row.Errors = append(row.Errors, EmployeeImportError{
+					Field:  "employee_number",
+					Column: imp.header[numberColumn],
+					Value:  number,
+					Reason: fmt.Sprintf("is already used by row %d", imp.rows[first].Row),
+				})
+			} else {
+				seen[number] = i
+			}
+		}
+		if len(row.Errors) > 0 {
+			action = ImportActionInvalid
+		}
+		row.Action = action
+	}
+	return nil
+}
+
+// importErrors describes the validation errors of a row with the columns and
+// cells the invalid values came from
+func importErrors(err error, header []string, fieldColumns map[string]int, cells []string) []EmployeeImportError {
+	params := invalidParams(err)
+	if params == nil {
+		params = []middleware.InvalidParam{{Name: "employee", Reason: err.Error()}}
+	}
+	errs := make([]EmployeeImportError, len(params))
+	for i, param := range params {
+		errs[i] = EmployeeImportError{Field: param.Name, Reason: param.Reason}
+		if column, ok := fieldColumns[param.Name]; ok {
+			errs[i].Column = header[column]
+			errs[i].Value = cell(cells, column)
+		}
+	}
+	return errs
+}
+
+// applyImport writes the planned creates and updates in one transaction
+func (h *EmployeeHandler) applyImport(c *gin.Context, imp *employeeImport) error {
+	return h.repo.Transaction(c.Request.Context(), func(repo repository.EmployeeRepository) error {
+		var created []*models.Employee
+		var createdRows []int
+		for i := range imp.rows {
+			switch imp.rows[i].Action {
+			case ImportActionCreate:
+				created = append(created, &imp.employees[i])
+				createdRows = append(createdRows, i)
+			case ImportActionUpdate:
+				// The row was planned from this version, so it must still be current
+				if _, err := repo.Update(c.Request.Context(), imp.rows[i].EmployeeID, &imp.employees[i], imp.versions[i]); err != nil {
+					return &importRowError{row: imp.rows[i].Row, id: imp.rows[i].EmployeeID, err: err}
+				}
+			}
+		}
+		if len(created) == 0 {
+			return nil
+		}
+		if err := repo.CreateBatch(c.Request.Context(), created); err != nil {
+			return err
+		}
+		for i, employee := range created {
+			imp.rows[createdRows[i]].EmployeeID = employee.ID
+		}
+		return nil
+	})
+}
+
+// importRowError is the failure of writing one row of an import
+type importRowError struct {
+	row int
+	id  uint
+	err error
+}
+
+func (e *importRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.row, e.err)
+}
+
+func (e *importRowError) Unwrap() error {
+	return e.err
+}
+
+// readImportFile returns the uploaded file with its name and media type,
+// either from the "file" field of a multipart form or from the raw body
+func readImportFile(c *gin.Context) ([]byte, string, string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	if c.ContentType() != binding.MIMEMultipartPOSTForm {
+		data, err := io.ReadAll(c.Request.Body)
+		return data, "", c.ContentType(), err
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, "", "", nil
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	return data, header.Filename, header.Header.Get("Content-Type"), err
+}
+
+// respondImportFileError writes the response for an upload that is not a
+// readable spreadsheet
+func respondImportFileError(c *gin.Context, err error) {
+	utils.LogValidationError(c, "file", nil, err, logrus.Fields{
+		"operation": "import_employees",
+	})
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		middleware.RespondError(c, http.StatusRequestEntityTooLarge, middleware.ErrorResponse{
+			Error: fmt.Sprintf("File must be at most %d MiB", maxImportSize>>20),
+			Code:  middleware.CodePayloadTooLarge,
+		})
+	case errors.Is(err, spreadsheet.ErrUnsupportedFormat):
+		middleware.RespondError(c, http.StatusUnsupportedMediaType, middleware.ErrorResponse{
+			Error: "File must be CSV or XLSX",
+			Code:  middleware.CodeUnsupportedMediaType,
+		})
+	case errors.Is(err, spreadsheet.ErrTooManyRows):
+		respondValidationError(c, validation.FieldError{
+			Field:  "file",
+			Reason: fmt.Sprintf("must have at most %d rows of employees", maxImportRows),
+		})
+	case errors.Is(err, spreadsheet.ErrInvalidFile):
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error: "Invalid file: " + strings.TrimPrefix(err.Error(), spreadsheet.ErrInvalidFile.Error()+": "),
+			Code:  middleware.CodeInvalidRequest,
+		})
+	case invalidParams(err) != nil:
+		respondValidationError(c, err)
+	default:
+		_ = c.Error(err)
+	}
+}
+
+// Import handles POST /employees/import, which creates and updates employees
+// from a CSV or XLSX file. Columns are matched to employee fields by header,
+// and rows to employees by employee number. A file with any invalid row
+// writes nothing and is answered with 422 and the report of every row;
+// dry_run=true validates and reports without writing. Clients accepting
+// text/csv get the report as a CSV file to correct and upload again.
+func (h *EmployeeHandler) Import(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	dryRun, err := parseBoolQuery(c, "dry_run")
+	if err != nil {
+		utils.LogValidationError(c, "dry_run", c.Query("dry_run"), err)
+		respondValidationError(c, err)
+		return
+	}
+
+	data, filename, contentType, err := readImportFile(c)
+	if err != nil {
+		respondImportFileError(c, err)
+		return
+	}
+	if len(data) == 0 {
+		respondImportFileError(c, validation.FieldError{Field: "file", Reason: "is required"})
+		return
+	}
+	format, err := spreadsheet.DetectFormat(filename, contentType, data)
+	if err != nil {
+		respondImportFileError(c, err)
+		return
+	}
+	records, err := spreadsheet.Read(data, format, maxImportRows+1)
+	if err != nil {
+		respondImportFileError(c, err)
+		return
+	}
+	if len(records) == 0 {
+		respondImportFileError(c, validation.FieldError{Field: "file", Reason: "must have a header row"})
+		return
+	}
+
+	imp := &employeeImport{header: records[0]}
+	imp.columns, err = mapImportColumns(imp.header, c.QueryMap("mapping"))
+	if err != nil {
+		respondImportFileError(c, err)
+		return
+	}
+	for i, cells := range records[1:] {
+		blank := true
+		for column := range cells {
+			if cell(cells, column) != "" {
+				blank = false
+				break
+			}
+		}
+		if blank {
+			continue
+		}
+		imp.cells = append(imp.cells, cells)
+		imp.rows = append(imp.rows, EmployeeImportRow{Row: i + 2})
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "import_employees",
+		"format":     format,
+		"rows":       len(imp.rows),
+		"dry_run":    dryRun,
+	}).Info("Processing import employees request")
+
+	if err := h.planImport(c, imp); err != nil {
+		respondDBError(c, "import_employees", err, "", "Failed to import employees")
+		return
+	}
+
+	report := EmployeeImportReport{
+		DryRun:         dryRun,
+		Columns:        make(map[string]string),
+		IgnoredColumns: []string{},
+		Rows:           imp.rows,
+	}
+	for i, field := range imp.columns {
+		if field == "" {
+			report.IgnoredColumns = append(report.IgnoredColumns, imp.header[i])
+		} else {
+			report.Columns[imp.header[i]] = field
+		}
+	}
+	for _, row := range imp.rows {
+		switch row.Action {
+		case ImportActionCreate:
+			report.Created++
+		case ImportActionUpdate:
+			report.Updated++
+		case ImportActionUnchanged:
+			report.Unchanged++
+		case ImportActionInvalid:
+			report.Invalid++
+		}
+	}
+
+	status := http.StatusOK
+	switch {
+	case report.Invalid > 0:
+		if !dryRun {
+			status = http.StatusUnprocessableEntity
+		}
+		utils.LogValidationError(c, "file", filename, fmt.Errorf("%d invalid rows", report.Invalid), logrus.Fields{
+			"operation": "import_employees",
+		})
+	case !dryRun:
+		err := h.applyImport(c, imp)
+		var rowErr *importRowError
+		if errors.As(err, &rowErr) {
+			respondEmployeeWriteError(c, "import_employees", rowErr.id, err, "Failed to import employees")
+			return
+		}
+		if err != nil {
+			respondDBError(c, "import_employees", err, "", "Failed to import employees")
+			return
+		}
+		report.Applied = true
+	}
+
+	// Log import outcome
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "import_employees",
+		"dry_run":    dryRun,
+		"applied":    report.Applied,
+		"created":    report.Created,
+		"updated":    report.Updated,
+		"unchanged":  report.Unchanged,
+		"invalid":    report.Invalid,
+	}).Info("Employee import processed")
+
+	if c.NegotiateFormat(binding.MIMEJSON, spreadsheet.CSVContentType) == spreadsheet.CSVContentType {
+		writeImportReportCSV(c, status, imp)
+		return
+	}
+	middleware.RestrictedJSON(c, status, report)
+}
+
+// writeImportReportCSV writes the rows of the file with their action, the ID
+// of their employee and their errors appended as columns
+func writeImportReportCSV(c *gin.Context, status int, imp *employeeImport) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := append(append([]string{}, imp.header...), "import_action", "import_employee_id", "import_errors")
+	_ = w.Write(header)
+	for i, row := range imp.rows {
+		cells := make([]string, len(imp.header), len(header))
+		copy(cells, imp.cells[i])
+		errs := make([]string, len(row.Errors))
+		for j, invalid := range row.Errors {
+			errs[j] = invalid.Field + " " + invalid.Reason
+		}
+		id := ""
+		if row.EmployeeID != 0 {
+			id = strconv.FormatUint(uint64(row.EmployeeID), 10)
+		}
+		_ = w.Write(append(cells, row.Action, id, strings.Join(errs, "; ")))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="employee-import-report.csv"`)
+	c.Data(status, spreadsheet.CSVContentType+"; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+// performUpload posts a file as the "file" field of a multipart form
+func performUpload(t *testing.T, router *gin.Engine, path, filename string, data []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// seedNumberedEmployees inserts employees with the given employee number, first and last name
+func seedNumberedEmployees(t *testing.T, repo repository.EmployeeRepository, employees ...[3]string) {
+	t.Helper()
+	for _, e := range employees {
+		require.NoError(t, repo.Create(context.Background(), &models.Employee{EmployeeNumber: e[0], FirstName: e[1], LastName: e[2]}))
+	}
+}
+
+// importReport decodes an import report
+func importReport(t *testing.T, body []byte) EmployeeImportReport {
+	t.Helper()
+	var report EmployeeImportReport
+	require.NoError(t, json.Unmarshal(body, &report))
+	return report
+}
+
+const importRoster = "Employee No,First Name,Surname,Notes\n" +
+	"E1,,King,married\n" +
+	"E2,Grace,Hopper,\n" +
+	",Edsger,Dijkstra,\n" +
+	",,,\n" +
+	"E4, Alan ,Turing,\n"
+
+func TestEmployeeImport(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedNumberedEmployees(t, repo, [3]string{"E1", "Ada", "Lovelace"}, [3]string{"E4", "Alan", "Turing"})
+
+	w := performConditional(router, http.MethodPost, "/employees/import", "Accept", "application/json", "text/csv", importRoster)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	report := importReport(t, w.Body.Bytes())
+	assert.True(t, report.Applied)
+	assert.Equal(t, map[string]string{"Employee No": "employee_number", "First Name": "first_name", "Surname": "last_name"}, report.Columns)
+	assert.Equal(t, []string{"Notes"}, report.IgnoredColumns)
+	assert.Equal(t, []int{2, 1, 1, 0}, []int{report.Created, report.Updated, report.Unchanged, report.Invalid})
+	assert.Equal(t, []EmployeeImportRow{
+		{Row: 2, Action: ImportActionUpdate, EmployeeID: 1, EmployeeNumber: "E1"},
+		{Row: 3, Action: ImportActionCreate, EmployeeID: 3, EmployeeNumber: "E2"},
+		{Row: 4, Action: ImportActionCreate, EmployeeID: 4},
+		{Row: 6, Action: ImportActionUnchanged, EmployeeID: 2, EmployeeNumber: "E4"},
+	}, report.Rows)
+
+	// Empty cells keep the current values of updated employees
+	updated, err := repo.Get(context.Background(), 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", updated.FirstName)
+	assert.Equal(t, "King", updated.LastName)
+	assert.Equal(t, uint(2), updated.Version)
+	created, err := repo.FindByEmployeeNumbers(context.Background(), []string{"E2"})
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+	assert.Equal(t, "Hopper", created[0].LastName)
+	assert.Equal(t, int64(4), countEmployees(t, repo))
+}
+
+func TestEmployeeImport_DryRun(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedNumberedEmployees(t, repo, [3]string{"E1", "Ada", "Lovelace"})
+
+	w := performConditional(router, http.MethodPost, "/employees/import?dry_run=true", "Accept", "application/json", "text/csv", importRoster)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	report := importReport(t, w.Body.Bytes())
+	assert.True(t, report.DryRun)
+	assert.False(t, report.Applied)
+	assert.Equal(t, 3, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Zero(t, report.Rows[1].EmployeeID)
+
+	current, err := repo.Get(context.Background(), 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Lovelace", current.LastName)
+	assert.Equal(t, int64(1), countEmployees(t, repo))
+}
+
+func TestEmployeeImport_InvalidRows(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedNumberedEmployees(t, repo, [3]string{"E1", "Ada", "Lovelace"})
+
+	roster := "employee_number,first_name,last_name\n" +
+		"E1,Ada,King\n" +
+		"E2,R2D2,\n" +
+		"E2,Grace,Hopper\n" +
+		"E 3,Alan,Turing\n"
+	w := performConditional(router, http.MethodPost, "/employees/import", "Accept", "application/json", "text/csv", roster)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	report := importReport(t, w.Body.Bytes())
+	assert.False(t, report.Applied)
+	assert.Equal(t, 3, report.Invalid)
+	assert.Equal(t, ImportActionUpdate, report.Rows[0].Action)
+	assert.Equal(t, []EmployeeImportError{
+		{Field: "first_name", Column: "first_name", Value: "R2D2", Reason: "must contain only letters, spaces, hyphens, apostrophes and periods"},
+		{Field: "last_name", Column: "last_name", Value: "", Reason: "is required"},
+	}, report.Rows[1].Errors)
+	assert.Equal(t, []EmployeeImportError{
+		{Field: "employee_number", Column: "employee_number", Value: "E2", Reason: "is already used by row 3"},
+	}, report.Rows[2].Errors)
+	assert.Equal(t, "employee_number", report.Rows[3].Errors[0].Field)
+
+	// Nothing is written while any row is invalid
+	current, err := repo.Get(context.Background(), 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Lovelace", current.LastName)
+	assert.Equal(t, int64(1), countEmployees(t, repo))
+
+	// The report can be downloaded as the file with the outcome of each row
+	w = performConditional(router, http.MethodPost, "/employees/import?dry_run=1", "Accept", "text/csv", "text/csv", roster)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="employee-import-report.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	assert.Equal(t, []string{"employee_number", "first_name", "last_name", "import_action", "import_employee_id", "import_errors"}, records[0])
+	assert.Equal(t, []string{"E1", "Ada", "King", "update", "1", ""}, records[1])
+	assert.Equal(t, []string{"E2", "R2D2", "", "invalid", "",
+		"first_name must contain only letters, spaces, hyphens, apostrophes and periods; last_name is required"}, records[2])
+}
+
+// buildImportXLSX builds a workbook with one worksheet of inline strings
+func buildImportXLSX(t *testing.T, rows ...[]string) []byte {
+	t.Helper()
+	var sheet strings.Builder
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for _, row := range rows {
+		sheet.WriteString("<row>")
+		for _, value := range row {
+			sheet.WriteString(`<c t="inlineStr"><is><t>` + value + `</t></is></c>`)
+		}
+		sheet.WriteString("</row>")
+	}
+	sheet.WriteString("</sheetData></worksheet>")
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	w, err := archive.Create("xl/worksheets/sheet1.xml")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(sheet.String()))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+func TestEmployeeImport_XLSXWithMapping(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+
+	data := buildImportXLSX(t,
+		[]string{"Badge", "Vorname", "Nachname"},
+		[]string{"B-7", "Grace", "Hopper"},
+	)
+	w := performUpload(t, router, "/employees/import?mapping[Badge]=employee_number&mapping[vorname]=first_name&mapping[Nachname]=last_name",
+		"roster.xlsx", data)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	report := importReport(t, w.Body.Bytes())
+	assert.Equal(t, 1, report.Created)
+	assert.Empty(t, report.IgnoredColumns)
+	employees, err := repo.FindByEmployeeNumbers(context.Background(), []string{"B-7"})
+	require.NoError(t, err)
+	require.Len(t, employees, 1)
+	assert.Equal(t, "Grace", employees[0].FirstName)
+}
+
+func TestEmployeeImport_Request(t *testing.T) {
+	router, _ := setupEmployeeRouter()
+
+	tooManyRows := "first_name,last_name\n" + strings.Repeat("Ada,Lovelace\n", maxImportRows+1)
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		status      int
+		want        string
+	}{
+		{
+			name: "no file", path: "/employees/import", contentType: "text/csv",
+			status: http.StatusBadRequest, want: `{"error":"file is required","code":"validation_failed"}`,
+		},
+		{
+			name: "legacy excel", path: "/employees/import", contentType: "application/vnd.ms-excel", body: "\xd0\xcf\x11\xe0\x00",
+			status: http.StatusUnsupportedMediaType, want: `{"error":"File must be CSV or XLSX","code":"unsupported_media_type"}`,
+		},
+		{
+			name: "broken workbook", path: "/employees/import", contentType: "application/octet-stream", body: "PK\x03\x04broken",
+			status: http.StatusBadRequest, want: `{"error":"Invalid file: zip: not a valid zip file","code":"invalid_request"}`,
+		},
+		{
+			name: "no known columns", path: "/employees/import", contentType: "text/csv", body: "name\nAda\n",
+			status: http.StatusBadRequest, want: `{"error":"file must have a column for one of: employee_number, first_name, last_name","code":"validation_failed"}`,
+		},
+		{
+			name: "unknown mapping", path: "/employees/import?mapping[name]=full_name&mapping[email]=last_name", contentType: "text/csv", body: "name\nAda\n",
+			status: http.StatusBadRequest,
+			want:   `{"error":"mapping[email] must name a column of the file; mapping[name] must be one of: employee_number, first_name, last_name","code":"validation_failed"}`,
+		},
+		{
+			name: "column mapped twice", path: "/employees/import", contentType: "text/csv", body: "first_name,Given Name\nAda,Ada\n",
+			status: http.StatusBadRequest, want: `{"error":"Given Name maps to first_name like column \"first_name\"","code":"validation_failed"}`,
+		},
+		{
+			name: "too many rows", path: "/employees/import", contentType: "text/csv", body: tooManyRows,
+			status: http.StatusBadRequest, want: `{"error":"file must have at most 10000 rows of employees","code":"validation_failed"}`,
+		},
+		{
+			name: "too large", path: "/employees/import", contentType: "text/csv", body: strings.Repeat("a", maxImportSize+1),
+			status: http.StatusRequestEntityTooLarge, want: `{"error":"File must be at most 10 MiB","code":"payload_too_large"}`,
+		},
+		{
+			name: "invalid dry run", path: "/employees/import?dry_run=maybe", contentType: "text/csv", body: "first_name\nAda\n",
+			status: http.StatusBadRequest, want: `{"error":"dry_run must be a boolean","code":"validation_failed"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performConditional(router, http.MethodPost, tt.path, "Accept", "application/json", tt.contentType, tt.body)
+			assert.Equal(t, tt.status, w.Code)
+			assert.JSONEq(t, tt.want, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/patch"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// acceptPatch lists the patch formats PATCH /employees/:id understands
+const acceptPatch = patch.MergePatchContentType + ", " + patch.JSONPatchContentType
+
+// employeeImmutableFields are the employee JSON fields a patch may not change
+var employeeImmutableFields = []string{"id", "created_at", "updated_at", "deleted_at", "version"}
+
+// applyPatch applies a patch body of the given content type to a document
+func applyPatch(contentType string, doc, body []byte) ([]byte, error) {
+	switch contentType {
+	case patch.MergePatchContentType:
+		return patch.MergePatch(doc, body)
+	default:
+		return patch.JSONPatch(doc, body)
+	}
+}
+
+// patchedMembers returns the top-level members a patch body of the given
+// content type reads or writes
+func patchedMembers(contentType string, body []byte) []string {
+	switch contentType {
+	case patch.MergePatchContentType:
+		return patch.MergePatchMembers(body)
+	default:
+		return patch.JSONPatchMembers(body)
+	}
+}
+
+// immutableChanges returns an error naming every immutable field whose value
+// differs between the current and the patched document
+func immutableChanges(current, patched []byte, fields []string) error {
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(current, &before); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return validation.FieldError{Field: "document", Reason: "must be a JSON object"}
+	}
+
+	var changed validation.Errors
+	for _, field := range fields {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changed = append(changed, validation.FieldError{Field: field, Reason: "cannot be changed"})
+		}
+	}
+	if len(changed) > 0 {
+		return changed
+	}
+	return nil
+}
+
+// decodePatched decodes a patched document, reporting fields of the wrong type
+func decodePatched(patched []byte, value interface{}) error {
+	err := json.Unmarshal(patched, value)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return validation.FieldError{Field: typeErr.Field, Reason: "must be a " + typeErr.Type.Kind().String()}
+	}
+	return err
+}
+
+// respondPatchError writes the response for a patch that could not be applied
+func respondPatchError(c *gin.Context, operation string, id uint, err error) {
+	utils.LogValidationError(c, "patch", id, err, logrus.Fields{
+		"operation": operation,
+	})
+	if errors.Is(err, patch.ErrCannotApply) {
+		middleware.RespondError(c, http.StatusConflict, middleware.ErrorResponse{
+			Error: err.Error(),
+			Code:  middleware.CodePatchConflict,
+		})
+		return
+	}
+	middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+		Error: err.Error(),
+		Code:  middleware.CodeInvalidRequest,
+	})
+}
+
+// Patch handles partially updating an employee with a JSON Merge Patch or a
+// JSON Patch. The patched employee is validated as a whole before it is saved.
+func (h *EmployeeHandler) Patch(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	employeeID, ok := parseEmployeeID(c)
+	if !ok {
+		return
+	}
+
+	contentType := c.ContentType()
+	if contentType != patch.MergePatchContentType && contentType != patch.JSONPatchContentType {
+		c.Header("Accept-Patch", acceptPatch)
+		middleware.RespondError(c, http.StatusUnsupportedMediaType, middleware.ErrorResponse{
+			Error: "Content-Type must be one of: " + acceptPatch,
+			Code:  middleware.CodeUnsupportedMediaType,
+		})
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id":   requestID,
+		"operation":    "patch_employee",
+		"employee_id":  employeeID,
+		"content_type": contentType,
+	}).Info("Processing patch employee request")
+
+	body, err := c.GetRawData()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Callers may neither change nor test fields they cannot see
+	if set := hiddenAmong(c, patchedMembers(contentType, body)); len(set) > 0 {
+		utils.LogBusinessError(c, "patch_employee", errors.New("patch touches hidden fields"), logrus.Fields{
+			"employee_id": employeeID,
+			"fields":      set,
+		})
+		middleware.RespondHiddenFieldsWritten(c, "", set)
+		return
+	}
+
+	current, err := h.repo.Get(c.Request.Context(), employeeID, false)
+	if err != nil {
+		respondEmployeeLookupError(c, "patch_employee", employeeID, err, "Failed to patch employee")
+		return
+	}
+	if !ifMatch(c, "patch_employee", current) {
+		return
+	}
+	stored, err := json.Marshal(current)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	doc, err := withoutHiddenFields(c, stored)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	patched, err := applyPatch(contentType, doc, body)
+	if err != nil {
+		respondPatchError(c, "patch_employee", employeeID, err)
+		return
+	}
+
+	var employee models.Employee
+	if err := immutableChanges(doc, patched, employeeImmutableFields); err != nil {
+		utils.LogValidationError(c, "employee_data", employeeID, err, logrus.Fields{
+			"operation": "patch_employee",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	if err := decodePatched(patched, &employee); err != nil {
+		utils.LogValidationError(c, "employee_data", employeeID, err, logrus.Fields{
+			"operation": "patch_employee",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	if err := validation.Struct(&employee); err != nil {
+		utils.LogValidationError(c, "employee_data", employee, err, logrus.Fields{
+			"operation":   "patch_employee",
+			"employee_id": employeeID,
+		})
+		respondValidationError(c, err)
+		return
+	}
+	if err := keepHiddenFields(c, current, &employee); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// The patch was computed from the current version, so it must still be current
+	updatedEmployee, err := h.repo.Update(c.Request.Context(), employeeID, &employee, current.Version)
+	if err != nil {
+		respondEmployeeWriteError(c, "patch_employee", employeeID, err, "Failed to patch employee")
+		return
+	}
+
+	// Log successful update
+	logger.WithFields(logrus.Fields{
+		"request_id":          requestID,
+		"operation":           "patch_employee",
+		"employee_id":         updatedEmployee.ID,
+		"employee_first_name": updatedEmployee.FirstName,
+		"employee_last_name":  updatedEmployee.LastName,
+	}).Info("Employee patched successfully")
+
+	c.Header("ETag", employeeETag(updatedEmployee))
+	middleware.RestrictedJSON(c, http.StatusOK, updatedEmployee)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/patch"
+	"github.com/yourname/employee-api/repository"
+)
+
+// performPatch sends a PATCH request with the given content type
+func performPatch(router *gin.Engine, path, contentType, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPatch, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPatchEmployeeHandler_MergePatch(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	w := performPatch(router, "/employees/1", patch.MergePatchContentType, `{"last_name":"King"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var employee models.Employee
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &employee))
+	assert.Equal(t, "Ada", employee.FirstName)
+	assert.Equal(t, "King", employee.LastName)
+
+	// null removes the field, which the required rule then rejects
+	w = performPatch(router, "/employees/1", patch.MergePatchContentType, `{"first_name":null}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"first_name is required","code":"validation_failed"}`, w.Body.String())
+}
+
+func TestPatchEmployeeHandler_JSONPatch(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	w := performPatch(router, "/employees/1", patch.JSONPatchContentType, `[
+		{"op":"test","path":"/first_name","value":"Ada"},
+		{"op":"replace","path":"/first_name","value":"Augusta"}
+	]`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var employee models.Employee
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &employee))
+	assert.Equal(t, "Augusta", employee.FirstName)
+	assert.Equal(t, "Lovelace", employee.LastName)
+}
+
+func TestPatchEmployeeHandler_Errors(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		status      int
+		code        string
+	}{
+		{name: "unsupported content type", path: "/employees/1", contentType: "application/json", body: `{}`, status: http.StatusUnsupportedMediaType, code: "unsupported_media_type"},
+		{name: "malformed merge patch", path: "/employees/1", contentType: patch.MergePatchContentType, body: `{`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "unknown op", path: "/employees/1", contentType: patch.JSONPatchContentType, body: `[{"op":"nope","path":"/id"}]`, status: http.StatusBadRequest, code: "invalid_request"},
+		{name: "failed test", path: "/employees/1", contentType: patch.JSONPatchContentType, body: `[{"op":"test","path":"/first_name","value":"Grace"}]`, status: http.StatusConflict, code: "patch_conflict"},
+		{name: "missing path", path: "/employees/1", contentType: patch.JSONPatchContentType, body: `[{"op":"replace","path":"/nickname","value":"x"}]`, status: http.StatusConflict, code: "patch_conflict"},
+		{name: "wrong type", path: "/employees/1", contentType: patch.MergePatchContentType, body: `{"first_name":5}`, status: http.StatusBadRequest, code: "validation_failed"},
+		{name: "unknown employee", path: "/employees/42", contentType: patch.MergePatchContentType, body: `{}`, status: http.StatusNotFound, code: "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performPatch(router, tt.path, tt.contentType, tt.body)
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+
+			var response map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response["code"])
+		})
+	}
+
+	w := performPatch(router, "/employees/1", "text/plain", "")
+	assert.Equal(t, acceptPatch, w.Header().Get("Accept-Patch"))
+}
+
+func TestPatchEmployeeHandler_ImmutableFields(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	w := performPatch(router, "/employees/1", patch.JSONPatchContentType, `[
+		{"op":"replace","path":"/id","value":7},
+		{"op":"replace","path":"/created_at","value":"2000-01-01T00:00:00Z"}
+	]`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"id cannot be changed; created_at cannot be changed","code":"validation_failed"}`, w.Body.String())
+
+	// Leaving immutable fields as they are is fine
+	w = performPatch(router, "/employees/1", patch.MergePatchContentType, `{"id":1,"last_name":"King"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestUpdateEmployeeHandler_ReplacesFields(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	// PUT is a full replacement: an omitted required field is an error, not "keep"
+	w := performRequest(router, http.MethodPut, "/employees/1", []byte(`{"last_name":"King"}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"first_name is required","code":"validation_failed"}`, w.Body.String())
+}
+
+func TestPatchEmployeeHandler_HiddenFields(t *testing.T) {
+	repo := repository.NewMemoryEmployeeRepository()
+	salary := 85000.0
+	require.NoError(t, repo.Create(context.Background(), &models.Employee{FirstName: "Ada", LastName: "Lovelace", Salary: &salary}))
+	router := setupAuthorizedEmployeeRouter(repo, editorPolicy(), "editor")
+
+	// Patches may neither set nor read the hidden salary
+	refused := []struct {
+		name, contentType, body string
+	}{
+		{"merge patch sets it", patch.MergePatchContentType, `{"salary":1}`},
+		{"merge patch removes it", patch.MergePatchContentType, `{"salary":null}`},
+		{"test op", patch.JSONPatchContentType, `[{"op":"test","path":"/salary","value":85000}]`},
+		{"copy op", patch.JSONPatchContentType, `[{"op":"copy","from":"/salary","path":"/last_name"}]`},
+		{"whole document", patch.JSONPatchContentType, `[{"op":"replace","path":"","value":{"first_name":"Ada","last_name":"King","salary":1}}]`},
+	}
+	for _, tt := range refused {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performPatch(router, "/employees/1", tt.contentType, tt.body)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.JSONEq(t, `{"error":"Permission employees:read_sensitive required","code":"forbidden"}`, w.Body.String())
+		})
+	}
+
+	// Other changes apply and the stored salary is kept
+	w := performPatch(router, "/employees/1", patch.JSONPatchContentType, `[{"op":"replace","path":"/last_name","value":"King"}]`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "salary")
+
+	employee, err := repo.Get(context.Background(), 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "King", employee.LastName)
+	require.NotNil(t, employee.Salary)
+	assert.Equal(t, salary, *employee.Salary)
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/search"
+	"github.com/yourname/employee-api/utils"
+)
+
+// maxSearchQueryLength bounds the q parameter of a search, in characters
+const maxSearchQueryLength = 100
+
+// SearchHighlight holds the names of a found employee as HTML, with the
+// matching words wrapped in <mark> elements
+type SearchHighlight struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// EmployeeSearchHit is an employee found by a search
+type EmployeeSearchHit struct {
+	Employee  models.Employee `json:"employee"`
+	Score     float64         `json:"score"`
+	Highlight SearchHighlight `json:"highlight"`
+}
+
+// SearchResponse is the body of GET /employees/search
+type SearchResponse struct {
+	Query  string              `json:"query"`
+	Data   []EmployeeSearchHit `json:"data"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+	Links  PageLinks           `json:"links"`
+}
+
+// parseSearchQuery validates the q parameter of a search and returns its terms
+func parseSearchQuery(query string) ([]string, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("q is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, fmt.Errorf("q must be at most %d characters", maxSearchQueryLength)
+	}
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return nil, errors.New("q must contain a letter or digit")
+	}
+	return terms, nil
+}
+
+// Search handles GET /employees/search, which finds current employees by
+// name. Names match words of the query by prefix or, to tolerate typos, by
+// trigram similarity; the best matches come first.
+func (h *EmployeeHandler) Search(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	query := c.Query("q")
+	terms, err := parseSearchQuery(query)
+	if err != nil {
+		utils.LogValidationError(c, "q", query, err, logrus.Fields{
+			"operation": "search_employees",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	page, err := parsePage(c)
+	if err != nil {
+		utils.LogValidationError(c, "query", c.Request.URL.RawQuery, err, logrus.Fields{
+			"operation": "search_employees",
+		})
+		respondValidationError(c, err)
+		return
+	}
+
+	// Log request start
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "search_employees",
+		"terms":      len(terms),
+		"limit":      page.Limit,
+	}).Info("Processing search employees request")
+
+	// One extra result tells whether there is a next page
+	fetch := page
+	fetch.Limit++
+	results, err := h.repo.Search(c.Request.Context(), query, fetch)
+	if err != nil {
+		respondDBError(c, "search_employees", err, "", "Failed to search employees")
+		return
+	}
+	hasMore := len(results) > page.Limit
+	if hasMore {
+		results = results[:page.Limit]
+	}
+
+	hits := make([]EmployeeSearchHit, len(results))
+	for i, result := range results {
+		hits[i] = EmployeeSearchHit{
+			Employee: result.Employee,
+			Score:    result.Score,
+			Highlight: SearchHighlight{
+				FirstName: search.Highlight(result.Employee.FirstName, terms),
+				LastName:  search.Highlight(result.Employee.LastName, terms),
+			},
+		}
+	}
+
+	// Log successful search
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "search_employees",
+		"count":      len(hits),
+	}).Info("Employees searched successfully")
+
+	middleware.RestrictedJSON(c, http.StatusOK, SearchResponse{
+		Query:  query,
+		Data:   hits,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+		Links:  pageLinks(c, page, hasMore),
+	})
+}
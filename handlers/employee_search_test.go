@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmployeeSearch(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seeded := seedEmployees(t, repo,
+		[2]string{"Ada", "Lovelace"},
+		[2]string{"Alan", "Turing"},
+		[2]string{"Ada", "King"},
+		[2]string{"Grace", "Hopper"},
+		[2]string{"Adam", "Smith"},
+	)
+	require.NoError(t, repo.Delete(context.Background(), seeded[2].ID, 0))
+
+	tests := []struct {
+		name       string
+		query      string
+		want       []string
+		highlights []SearchHighlight
+	}{
+		{
+			name: "prefix", query: "ada",
+			want: []string{"Lovelace", "Smith"},
+			highlights: []SearchHighlight{
+				{FirstName: "<mark>Ada</mark>", LastName: "Lovelace"},
+				{FirstName: "<mark>Adam</mark>", LastName: "Smith"},
+			},
+		},
+		{
+			name: "every term", query: "grace hop",
+			want: []string{"Hopper"},
+			highlights: []SearchHighlight{
+				{FirstName: "<mark>Grace</mark>", LastName: "<mark>Hopper</mark>"},
+			},
+		},
+		{
+			name: "typo", query: "lovelase",
+			want: []string{"Lovelace"},
+			highlights: []SearchHighlight{
+				{FirstName: "Ada", LastName: "<mark>Lovelace</mark>"},
+			},
+		},
+		{name: "no match", query: "babbage", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodGet, "/employees/search?q="+strings.ReplaceAll(tt.query, " ", "+"), nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var response SearchResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.query, response.Query)
+			lastNames := []string{}
+			highlights := []SearchHighlight{}
+			for i, hit := range response.Data {
+				lastNames = append(lastNames, hit.Employee.LastName)
+				highlights = append(highlights, hit.Highlight)
+				assert.Positive(t, hit.Score)
+				if i > 0 {
+					assert.LessOrEqual(t, hit.Score, response.Data[i-1].Score)
+				}
+			}
+			assert.Equal(t, tt.want, lastNames)
+			if tt.highlights != nil {
+				assert.Equal(t, tt.highlights, highlights)
+			}
+		})
+	}
+}
+
+func TestEmployeeSearch_Pagination(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedEmployees(t, repo, [2]string{"Ada", "Lovelace"}, [2]string{"Adam", "Smith"})
+
+	w := performRequest(router, http.MethodGet, "/employees/search?q=ada&limit=1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response SearchResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "Lovelace", response.Data[0].Employee.LastName)
+	assert.Equal(t, "/employees/search?limit=1&offset=1&q=ada", response.Links.Next)
+
+	w = performRequest(router, http.MethodGet, response.Links.Next, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	response = SearchResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "Smith", response.Data[0].Employee.LastName)
+	assert.Empty(t, response.Links.Next)
+}
+
+func TestEmployeeSearch_InvalidQuery(t *testing.T) {
+	router, _ := setupEmployeeRouter()
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "missing", path: "/employees/search", want: "q is required"},
+		{name: "blank", path: "/employees/search?q=+++", want: "q is required"},
+		{name: "too long", path: "/employees/search?q=" + strings.Repeat("a", maxSearchQueryLength+1), want: "q must be at most 100 characters"},
+		{name: "no words", path: "/employees/search?q=--", want: "q must contain a letter or digit"},
+		{name: "invalid limit", path: "/employees/search?q=ada&limit=0", want: "limit must be an integer between 1 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodGet, tt.path, nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"error":"`+tt.want+`","code":"validation_failed"}`, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// respondDBError logs a failed repository call and writes the response its
+// error translates to. notFound and failure word the 404 and 500 responses,
+// which depend on the resource and operation; an empty notFound keeps the
+// generic wording for operations that do not look up a single resource.
+func respondDBError(c *gin.Context, operation string, err error, notFound, failure string, fields ...logrus.Fields) {
+	utils.LogDBError(c, operation, err, fields...)
+
+	translated := utils.TranslateDBError(err)
+	message := translated.Message
+	switch translated.Code {
+	case utils.ErrCodeNotFound:
+		if notFound != "" {
+			message = notFound
+		}
+	case utils.ErrCodeInternal:
+		message = failure
+	}
+	if translated.Retryable {
+		c.Header("Retry-After", "1")
+	}
+
+	middleware.RespondError(c, translated.Status, middleware.ErrorResponse{
+		Error: message,
+		Code:  translated.Code,
+	})
+}
+
+// respondValidationError writes the 400 response for a request with invalid
+// values. Fields reported by the validation package are also listed under the
+// problem's invalid-params.
+func respondValidationError(c *gin.Context, err error) {
+	middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+		Error:         err.Error(),
+		Code:          middleware.CodeValidationFailed,
+		InvalidParams: invalidParams(err),
+	})
+}
+
+// invalidParams lists the fields named by a validation error, or nil for other errors
+func invalidParams(err error) []middleware.InvalidParam {
+	var fieldErrs validation.Errors
+	var fieldErr validation.FieldError
+	switch {
+	case errors.As(err, &fieldErrs):
+		params := make([]middleware.InvalidParam, len(fieldErrs))
+		for i, invalid := range fieldErrs {
+			params[i] = middleware.InvalidParam{Name: invalid.Field, Reason: invalid.Reason}
+		}
+		return params
+	case errors.As(err, &fieldErr):
+		return []middleware.InvalidParam{{Name: fieldErr.Field, Reason: fieldErr.Reason}}
+	default:
+		return nil
+	}
+}
+
+// NotFound writes the 404 response for a path no route serves
+func NotFound(c *gin.Context) {
+	middleware.RespondError(c, http.StatusNotFound, middleware.ErrorResponse{
+		Error: "Resource not found",
+		Code:  utils.ErrCodeNotFound,
+	})
+}
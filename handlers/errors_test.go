@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+// failingEmployeeRepository fails every create with a fixed error
+type failingEmployeeRepository struct {
+	*repository.MemoryEmployeeRepository
+	err error
+}
+
+func (r *failingEmployeeRepository) Create(ctx context.Context, employee *models.Employee) error {
+	return r.err
+}
+
+func TestRespondDBError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		body       string
+		retryAfter string
+	}{
+		{
+			name:   "foreign key violation",
+			err:    &pgconn.PgError{Code: "23503"},
+			status: http.StatusUnprocessableEntity,
+			body:   `{"error":"A referenced resource does not exist or is still referenced","code":"foreign_key_violation"}`,
+		},
+		{
+			name:   "check violation",
+			err:    &pgconn.PgError{Code: "23514"},
+			status: http.StatusBadRequest,
+			body:   `{"error":"Request violates a data constraint","code":"constraint_violation"}`,
+		},
+		{
+			name:       "serialization failure",
+			err:        &pgconn.PgError{Code: "40001"},
+			status:     http.StatusServiceUnavailable,
+			body:       `{"error":"Conflicting concurrent update, retry the request","code":"retryable"}`,
+			retryAfter: "1",
+		},
+		{
+			name:   "statement timeout",
+			err:    &pgconn.PgError{Code: "57014"},
+			status: http.StatusGatewayTimeout,
+			body:   `{"error":"Database query timed out","code":"timeout"}`,
+		},
+		{
+			name:   "canceled",
+			err:    context.Canceled,
+			status: 499,
+			body:   `{"error":"Request was canceled","code":"canceled"}`,
+		},
+		{
+			name:   "unknown",
+			err:    assert.AnError,
+			status: http.StatusInternalServerError,
+			body:   `{"error":"Failed to create employee","code":"internal_error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewEmployeeHandler(&failingEmployeeRepository{
+				MemoryEmployeeRepository: repository.NewMemoryEmployeeRepository(),
+				err:                      tt.err,
+			})
+			router := setupTestRouter()
+			router.POST("/employees", h.Create)
+
+			w := performRequest(router, http.MethodPost, "/employees", []byte(`{"first_name":"Ada","last_name":"Lovelace"}`))
+			assert.Equal(t, tt.status, w.Code)
+			assert.JSONEq(t, tt.body, w.Body.String())
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestValidationProblems(t *testing.T) {
+	router, _ := setupEmployeeRouter()
+	serve := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/employees", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", middleware.ProblemContentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("missing field", func(t *testing.T) {
+		w := serve(`{"last_name":"Lovelace"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"type": "urn:employee-api:problem:validation_failed",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "first_name is required",
+			"instance": "test-request-id",
+			"code": "validation_failed",
+			"invalid-params": [{"name": "first_name", "reason": "is required"}]
+		}`, w.Body.String())
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		w := serve(`{"first_name":`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"type": "urn:employee-api:problem:invalid_request",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid request format",
+			"instance": "test-request-id",
+			"code": "invalid_request"
+		}`, w.Body.String())
+	})
+}
+
+func TestValidationProblems_AllFields(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	employee := seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})[0]
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		params string
+	}{
+		{
+			name:   "create",
+			method: http.MethodPost,
+			path:   "/employees",
+			body:   `{"last_name":"L0velace"}`,
+			params: `[
+				{"name": "first_name", "reason": "is required"},
+				{"name": "last_name", "reason": "must contain only letters, spaces, hyphens, apostrophes and periods"}
+			]`,
+		},
+		{
+			name:   "update",
+			method: http.MethodPut,
+			path:   fmt.Sprintf("/employees/%d", employee.ID),
+			body:   `{"first_name":"Ada!"}`,
+			params: `[
+				{"name": "first_name", "reason": "must contain only letters, spaces, hyphens, apostrophes and periods"},
+				{"name": "last_name", "reason": "is required"}
+			]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", middleware.ProblemContentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var problem struct {
+				InvalidParams json.RawMessage `json:"invalid-params"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.JSONEq(t, tt.params, string(problem.InvalidParams))
+		})
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	router := setupTestRouter()
+	router.NoRoute(NotFound)
+
+	w := performRequest(router, http.MethodGet, "/nope", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"Resource not found","code":"not_found"}`, w.Body.String())
+}
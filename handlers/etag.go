@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
+)
+
+// employeeETag returns the entity tag of an employee's current version
+func employeeETag(employee *models.Employee) string {
+	return fmt.Sprintf(`"%d"`, employee.Version)
+}
+
+// etagListMatches reports whether an If-Match or If-None-Match header lists
+// an entity tag; "*" matches any. Weak comparison ignores the W/ prefix,
+// strong comparison never matches weak tags.
+func etagListMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatch checks the If-Match precondition of a write against the current
+// employee. On mismatch it writes a 412 response and returns false.
+func ifMatch(c *gin.Context, operation string, employee *models.Employee) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" || etagListMatches(header, employeeETag(employee), false) {
+		return true
+	}
+	respondVersionConflict(c, operation, employee.ID, repository.ErrVersionConflict)
+	return false
+}
+
+// ifMatchVersion returns the version a conditional write must find: 0 without
+// If-Match, otherwise the version of the current employee if it matches. On
+// failure it writes the response and returns false.
+func (h *EmployeeHandler) ifMatchVersion(c *gin.Context, operation string, id uint) (uint, bool) {
+	if c.GetHeader("If-Match") == "" {
+		return 0, true
+	}
+	current, err := h.repo.Get(c.Request.Context(), id, false)
+	if err != nil {
+		respondEmployeeLookupError(c, operation, id, err, "Failed to retrieve employee")
+		return 0, false
+	}
+	if !ifMatch(c, operation, current) {
+		return 0, false
+	}
+	return current.Version, true
+}
+
+// respondVersionConflict writes the response for a write that found the
+// employee at another version: 412 when the client sent If-Match, otherwise
+// 409 for a concurrent update between reading and writing
+func respondVersionConflict(c *gin.Context, operation string, id uint, err error) {
+	utils.LogBusinessError(c, operation, err, logrus.Fields{
+		"employee_id": id,
+		"if_match":    c.GetHeader("If-Match"),
+	})
+	if c.GetHeader("If-Match") != "" {
+		middleware.RespondError(c, http.StatusPreconditionFailed, middleware.ErrorResponse{
+			Error: "Employee has been modified; fetch it again and retry",
+			Code:  middleware.CodePreconditionFailed,
+		})
+		return
+	}
+	middleware.RespondError(c, http.StatusConflict, middleware.ErrorResponse{
+		Error: "Employee was modified concurrently; retry the request",
+		Code:  middleware.CodeVersionConflict,
+	})
+}
+
+// respondEmployeeWriteError writes the response for a failed update or delete
+func respondEmployeeWriteError(c *gin.Context, operation string, id uint, err error, failure string) {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		respondVersionConflict(c, operation, id, err)
+		return
+	}
+	respondEmployeeLookupError(c, operation, id, err, failure)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/patch"
+)
+
+// performConditional sends a request with a precondition header
+func performConditional(router *gin.Engine, method, path, header, value, contentType, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set(header, value)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestEmployeeETags(t *testing.T) {
+	router, _ := setupEmployeeRouter()
+
+	w := performRequest(router, http.MethodPost, "/employees", []byte(`{"first_name":"Ada","last_name":"Lovelace"}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+	w = performRequest(router, http.MethodGet, "/employees/1", nil)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"version":1`)
+
+	// If-None-Match uses weak comparison and accepts lists
+	w = performConditional(router, http.MethodGet, "/employees/1", "If-None-Match", `"7", W/"1"`, "", "")
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+	w = performRequest(router, http.MethodPut, "/employees/1", []byte(`{"first_name":"Ada","last_name":"King"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	w = performConditional(router, http.MethodGet, "/employees/1", "If-None-Match", `"1"`, "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestEmployeeIfMatch(t *testing.T) {
+	router, repo := setupEmployeeRouter()
+	seedEmployees(t, repo, [2]string{"Ada", "Lovelace"})
+
+	body := `{"first_name":"Ada","last_name":"King"}`
+
+	// A stale version is rejected on every write method
+	w := performConditional(router, http.MethodPut, "/employees/1", "If-Match", `"2"`, "application/json", body)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.JSONEq(t, `{"error":"Employee has been modified; fetch it again and retry","code":"precondition_failed"}`, w.Body.String())
+
+	w = performConditional(router, http.MethodPatch, "/employees/1", "If-Match", `"2"`, patch.MergePatchContentType, `{"last_name":"King"}`)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	w = performConditional(router, http.MethodDelete, "/employees/1", "If-Match", `"2"`, "", "")
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// Weak tags never match If-Match
+	w = performConditional(router, http.MethodPut, "/employees/1", "If-Match", `W/"1"`, "application/json", body)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// The current version is accepted, and the next writer with it loses
+	w = performConditional(router, http.MethodPut, "/employees/1", "If-Match", `"1"`, "application/json", body)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+	w = performConditional(router, http.MethodPatch, "/employees/1", "If-Match", `"1"`, patch.MergePatchContentType, `{"first_name":"Augusta"}`)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	w = performConditional(router, http.MethodPatch, "/employees/1", "If-Match", `"2"`, patch.MergePatchContentType, `{"first_name":"Augusta"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	w = performConditional(router, http.MethodDelete, "/employees/1", "If-Match", "*", "", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = performConditional(router, http.MethodDelete, "/employees/1", "If-Match", "*", "", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEtagListMatches(t *testing.T) {
+	assert.True(t, etagListMatches(`"1"`, `"1"`, false))
+	assert.True(t, etagListMatches(`"0", "1"`, `"1"`, false))
+	assert.True(t, etagListMatches(`*`, `"1"`, false))
+	assert.False(t, etagListMatches(`W/"1"`, `"1"`, false))
+	assert.True(t, etagListMatches(`W/"1"`, `"1"`, true))
+	assert.False(t, etagListMatches(`"2"`, `"1"`, true))
+}
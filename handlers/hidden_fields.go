@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/employee-api/middleware"
+)
+
+// jsonMembers returns the members of value's JSON encoding, which must be an object
+func jsonMembers(value interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// hiddenAmong returns the given fields that are hidden from the caller
+func hiddenAmong(c *gin.Context, fields []string) []string {
+	var hidden []string
+	for _, field := range c.GetStringSlice(middleware.HiddenFieldsKey) {
+		for _, candidate := range fields {
+			if candidate == field {
+				hidden = append(hidden, field)
+				break
+			}
+		}
+	}
+	return hidden
+}
+
+// hiddenFieldsSet returns the fields hidden from the caller that value sets,
+// i.e. that appear in its JSON encoding
+func hiddenFieldsSet(c *gin.Context, value interface{}) ([]string, error) {
+	if len(c.GetStringSlice(middleware.HiddenFieldsKey)) == 0 {
+		return nil, nil
+	}
+
+	members, err := jsonMembers(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, 0, len(members))
+	for field := range members {
+		fields = append(fields, field)
+	}
+	return hiddenAmong(c, fields), nil
+}
+
+// withoutHiddenFields removes the fields hidden from the caller from a JSON object
+func withoutHiddenFields(c *gin.Context, doc []byte) ([]byte, error) {
+	hidden := c.GetStringSlice(middleware.HiddenFieldsKey)
+	if len(hidden) == 0 {
+		return doc, nil
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &members); err != nil {
+		return nil, err
+	}
+	for _, field := range hidden {
+		delete(members, field)
+	}
+	return json.Marshal(members)
+}
+
+// keepHiddenFields copies the fields hidden from the caller from stored into
+// replacement, so a write leaves the values the caller cannot see unchanged
+func keepHiddenFields(c *gin.Context, stored, replacement interface{}) error {
+	return keepFields(c.GetStringSlice(middleware.HiddenFieldsKey), stored, replacement)
+}
+
+// keepFields copies the named JSON fields from stored into replacement
+func keepFields(fields []string, stored, replacement interface{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	members, err := jsonMembers(stored)
+	if err != nil {
+		return err
+	}
+	kept := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		// An omitted value is empty; null clears it in the replacement too
+		value, ok := members[field]
+		if !ok {
+			value = json.RawMessage("null")
+		}
+		kept[field] = value
+	}
+	data, err := json.Marshal(kept)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, replacement)
+}
@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+const (
+	// defaultPageLimit is the page size used when the client does not send one
+	defaultPageLimit = 20
+	// maxPageLimit caps the page size a client may request
+	maxPageLimit = 100
+
+	paginationOffset = "offset"
+	paginationCursor = "cursor"
+
+	cursorNext = "next"
+	cursorPrev = "prev"
+)
+
+// columnKind describes how values of a sortable column are encoded in cursors
+type columnKind int
+
+const (
+	kindUint columnKind = iota
+	kindString
+	kindTime
+)
+
+// employeeSortColumns is the whitelist of columns employees may be sorted by
+var employeeSortColumns = map[string]columnKind{
+	"id":         kindUint,
+	"first_name": kindString,
+	"last_name":  kindString,
+	"created_at": kindTime,
+	"updated_at": kindTime,
+}
+
+// Cursor identifies a position in a keyset-paginated result set
+type Cursor struct {
+	// Sort is the raw sort expression the cursor was issued for
+	Sort string `json:"s"`
+	// Values holds the boundary row's value for every sort field
+	Values []interface{} `json:"v"`
+	// Direction is either "next" or "prev"
+	Direction string `json:"d"`
+}
+
+// EmployeeListParams holds the parsed query parameters of a list request
+type EmployeeListParams struct {
+	Mode     string
+	Cursor   *Cursor
+	SortExpr string
+	Options  repository.EmployeeListOptions
+}
+
+// PageLinks holds the links to adjacent pages
+type PageLinks struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// ListResponse is the envelope returned by list endpoints
+type ListResponse struct {
+	Data   interface{} `json:"data"`
+	Total  int64       `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset *int        `json:"offset,omitempty"`
+	Links  PageLinks   `json:"links"`
+}
+
+// parseEmployeeListParams parses and validates the query string of GET /employees
+func parseEmployeeListParams(c *gin.Context) (*EmployeeListParams, error) {
+	params := &EmployeeListParams{
+		Mode: paginationOffset,
+		Options: repository.EmployeeListOptions{
+			Limit: defaultPageLimit,
+		},
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		return nil, err
+	}
+	params.Options.Limit = page.Limit
+	params.Options.Offset = page.Offset
+
+	switch mode := c.DefaultQuery("pagination", paginationOffset); mode {
+	case paginationOffset, paginationCursor:
+		params.Mode = mode
+	default:
+		return nil, fmt.Errorf("pagination must be one of: %s, %s", paginationOffset, paginationCursor)
+	}
+
+	sort, err := parseSort(c.Query("sort"))
+	if err != nil {
+		return nil, err
+	}
+	params.SortExpr = c.Query("sort")
+	params.Options.Sort = sort
+
+	if raw := c.Query("cursor"); raw != "" {
+		if c.Query("offset") != "" {
+			return nil, fmt.Errorf("cursor and offset cannot be combined")
+		}
+		cursor, err := decodeCursor(raw, params.SortExpr, params.Options.Sort)
+		if err != nil {
+			return nil, err
+		}
+		params.Mode = paginationCursor
+		params.Cursor = cursor
+		params.Options.Keyset = &repository.Keyset{
+			Values:    cursor.Values,
+			Backwards: cursor.Direction == cursorPrev,
+		}
+	}
+
+	filter, err := parseEmployeeFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	params.Options.Filter = filter
+
+	return params, nil
+}
+
+// parseEmployeeFilter parses the filter query parameters shared by the
+// employee list and export endpoints
+func parseEmployeeFilter(c *gin.Context) (repository.EmployeeFilter, error) {
+	var filter repository.EmployeeFilter
+
+	includeDeleted, err := parseBoolQuery(c, "include_deleted")
+	if err != nil {
+		return filter, err
+	}
+	filter.IncludeDeleted = includeDeleted
+
+	filter.FirstName = strings.TrimSpace(c.Query("first_name"))
+	filter.LastName = strings.TrimSpace(c.Query("last_name"))
+
+	if raw := c.Query("created_after"); raw != "" {
+		t, err := parseTimeParam(raw)
+		if err != nil {
+			return filter, fmt.Errorf("created_after must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		}
+		filter.CreatedAfter = &t
+	}
+
+	if raw := c.Query("created_before"); raw != "" {
+		t, err := parseTimeParam(raw)
+		if err != nil {
+			return filter, fmt.Errorf("created_before must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		}
+		filter.CreatedBefore = &t
+	}
+
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && filter.CreatedAfter.After(*filter.CreatedBefore) {
+		return filter, fmt.Errorf("created_after must not be later than created_before")
+	}
+
+	if raw := c.Query("department_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 0)
+		if err != nil || id == 0 {
+			return filter, fmt.Errorf("department_id must be a positive integer")
+		}
+		filter.DepartmentID = uint(id)
+	}
+
+	return filter, nil
+}
+
+// parsePage parses the limit and offset query parameters of a list request
+func parsePage(c *gin.Context) (repository.Page, error) {
+	page := repository.Page{Limit: defaultPageLimit}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return page, fmt.Errorf("limit must be an integer between 1 and %d", maxPageLimit)
+		}
+		page.Limit = limit
+	}
+
+	if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return page, fmt.Errorf("offset must be a non-negative integer")
+		}
+		page.Offset = offset
+	}
+
+	return page, nil
+}
+
+// pageLinks builds the next/prev links of an offset-paginated page
+func pageLinks(c *gin.Context, page repository.Page, hasMore bool) PageLinks {
+	var links PageLinks
+
+	if hasMore {
+		links.Next = pageURL(c, map[string]string{
+			"offset": strconv.Itoa(page.Offset + page.Limit),
+		})
+	}
+	if page.Offset > 0 {
+		prev := page.Offset - page.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links.Prev = pageURL(c, map[string]string{
+			"offset": strconv.Itoa(prev),
+		})
+	}
+
+	return links
+}
+
+// parseBoolQuery parses an optional boolean query parameter
+func parseBoolQuery(c *gin.Context, key string) (bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean", key)
+	}
+	return value, nil
+}
+
+// parseSort parses a sort expression such as "last_name,-created_at".
+// The id column is always appended as a tie-breaker so that ordering is total.
+func parseSort(expr string) ([]repository.SortField, error) {
+	var fields []repository.SortField
+	seen := make(map[string]bool)
+
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		field := repository.SortField{Column: term}
+		switch term[0] {
+		case '-':
+			field.Column = term[1:]
+			field.Desc = true
+		case '+':
+			field.Column = term[1:]
+		}
+
+		if _, ok := employeeSortColumns[field.Column]; !ok {
+			return nil, fmt.Errorf("invalid sort field: %s", field.Column)
+		}
+		if seen[field.Column] {
+			return nil, fmt.Errorf("duplicate sort field: %s", field.Column)
+		}
+		seen[field.Column] = true
+		fields = append(fields, field)
+	}
+
+	if !seen["id"] {
+		fields = append(fields, repository.SortField{Column: "id"})
+	}
+	return fields, nil
+}
+
+// parseTimeParam accepts either an RFC 3339 timestamp or a plain date
+func parseTimeParam(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
+
+// encodeCursor builds an opaque cursor pointing at the given boundary row values
+func encodeCursor(sortExpr string, values []interface{}, direction string) string {
+	payload, _ := json.Marshal(Cursor{Sort: sortExpr, Values: values, Direction: direction})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeCursor decodes an opaque cursor and checks it matches the requested sort
+func decodeCursor(raw, sortExpr string, sort []repository.SortField) (*Cursor, error) {
+	invalid := fmt.Errorf("invalid cursor")
+
+	payload, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, invalid
+	}
+	if cursor.Direction != cursorNext && cursor.Direction != cursorPrev {
+		return nil, invalid
+	}
+	if cursor.Sort != sortExpr {
+		return nil, fmt.Errorf("cursor was issued for a different sort order")
+	}
+	if len(cursor.Values) != len(sort) {
+		return nil, invalid
+	}
+
+	// Restore the typed value of every boundary column
+	for i, field := range sort {
+		value, err := decodeCursorValue(employeeSortColumns[field.Column], cursor.Values[i])
+		if err != nil {
+			return nil, invalid
+		}
+		cursor.Values[i] = value
+	}
+
+	return &cursor, nil
+}
+
+// decodeCursorValue converts a JSON-decoded cursor value to its column type
+func decodeCursorValue(kind columnKind, raw interface{}) (interface{}, error) {
+	switch kind {
+	case kindUint:
+		n, ok := raw.(float64)
+		if !ok || n < 0 {
+			return nil, fmt.Errorf("expected unsigned integer")
+		}
+		return uint(n), nil
+	case kindTime:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected timestamp")
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	default:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string")
+		}
+		return s, nil
+	}
+}
+
+// employeeCursorValues extracts the cursor values of an employee for the given sort
+func employeeCursorValues(sort []repository.SortField, employee *models.Employee) []interface{} {
+	values := make([]interface{}, len(sort))
+	for i, field := range sort {
+		values[i] = repository.EmployeeColumnValue(employee, field.Column)
+	}
+	return values
+}
+
+// pageURL returns the request URL with the given query parameters replaced
+func pageURL(c *gin.Context, set map[string]string, remove ...string) string {
+	u := url.URL{Path: c.Request.URL.Path}
+	query := c.Request.URL.Query()
+	for _, key := range remove {
+		query.Del(key)
+	}
+	for key, value := range set {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+func TestParseSort_AppendsIDTieBreaker(t *testing.T) {
+	sort, err := parseSort("last_name,-created_at")
+	assert.NoError(t, err)
+	assert.Equal(t, []repository.SortField{
+		{Column: "last_name"},
+		{Column: "created_at", Desc: true},
+		{Column: "id"},
+	}, sort)
+}
+
+func TestParseSort_KeepsExplicitID(t *testing.T) {
+	sort, err := parseSort("-id")
+	assert.NoError(t, err)
+	assert.Equal(t, []repository.SortField{{Column: "id", Desc: true}}, sort)
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	sort, err := parseSort("-created_at")
+	assert.NoError(t, err)
+
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 123456000, time.UTC)
+	employee := models.Employee{Base: models.Base{ID: 42, CreatedAt: createdAt}}
+	raw := encodeCursor("-created_at", employeeCursorValues(sort, &employee), cursorNext)
+
+	cursor, err := decodeCursor(raw, "-created_at", sort)
+	assert.NoError(t, err)
+	assert.Equal(t, cursorNext, cursor.Direction)
+	assert.Equal(t, createdAt, cursor.Values[0])
+	assert.Equal(t, uint(42), cursor.Values[1])
+}
+
+func TestCursor_RejectsDifferentSort(t *testing.T) {
+	sort, err := parseSort("last_name")
+	assert.NoError(t, err)
+
+	raw := encodeCursor("first_name", []interface{}{"Ada", uint(1)}, cursorNext)
+	_, err = decodeCursor(raw, "last_name", sort)
+	assert.EqualError(t, err, "cursor was issued for a different sort order")
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// includeUser is the include value that loads a post's author
+const includeUser = "user"
+
+// PostHandler serves the post endpoints
+type PostHandler struct {
+	repo repository.PostRepository
+}
+
+// NewPostHandler creates a post handler backed by the given repository
+func NewPostHandler(repo repository.PostRepository) *PostHandler {
+	return &PostHandler{repo: repo}
+}
+
+// parseIncludeUser parses the include query parameter; only "user" is supported
+func parseIncludeUser(c *gin.Context) (bool, error) {
+	include := false
+	for _, value := range strings.Split(c.Query("include"), ",") {
+		switch strings.TrimSpace(value) {
+		case "":
+		case includeUser:
+			include = true
+		default:
+			return false, fmt.Errorf("include must be one of: %s", includeUser)
+		}
+	}
+	return include, nil
+}
+
+// validatePost normalizes and checks a post body. A partial body, as sent to
+// update, may leave fields empty to keep their current values.
+func validatePost(post *models.Post, partial bool) error {
+	post.Title = strings.TrimSpace(post.Title)
+	if partial {
+		return validation.Partial(post)
+	}
+	return validation.Struct(post)
+}
+
+// respondPostLookupError writes the response for a failed post lookup
+func respondPostLookupError(c *gin.Context, operation string, id uint, err error, failure string) {
+	respondDBError(c, operation, err, "Post not found", failure, logrus.Fields{
+		"post_id": id,
+	})
+}
+
+// Create handles the creation of a new post. Under /users/:id/posts the
+// author is taken from the path, otherwise from user_id in the body.
+func (h *PostHandler) Create(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	var pathUserID uint
+	if c.Param("id") != "" {
+		var ok bool
+		if pathUserID, ok = parseIDParam(c, "id", "user"); !ok {
+			return
+		}
+	}
+
+	var post models.Post
+	if err := c.ShouldBindJSON(&post); err != nil {
+		utils.LogValidationError(c, "post_data", post, err, logrus.Fields{
+			"operation": "create_post",
+		})
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
+		})
+		return
+	}
+	post.User = nil
+	if pathUserID != 0 {
+		post.UserID = pathUserID
+	}
+	if err := validatePost(&post, false); err != nil {
+		utils.LogValidationError(c, "post_data", post, err)
+		respondValidationError(c, err)
+		return
+	}
+
+	if err := h.repo.Create(c.Request.Context(), &post); err != nil {
+		respondUserLookupError(c, "create_post", post.UserID, err, "Failed to create post")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "create_post",
+		"post_id":    post.ID,
+		"user_id":    post.UserID,
+	}).Info("Post created successfully")
+
+	c.JSON(http.StatusCreated, post)
+}
+
+// Get handles retrieving a post by ID
+func (h *PostHandler) Get(c *gin.Context) {
+	postID, ok := parseIDParam(c, "id", "post")
+	if !ok {
+		return
+	}
+
+	include, err := parseIncludeUser(c)
+	if err != nil {
+		utils.LogValidationError(c, "include", c.Query("include"), err)
+		respondValidationError(c, err)
+		return
+	}
+
+	post, err := h.repo.Get(c.Request.Context(), postID, include)
+	if err != nil {
+		respondPostLookupError(c, "get_post", postID, err, "Failed to retrieve post")
+		return
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
+// List handles listing posts with pagination, optionally those of the user in the path
+func (h *PostHandler) List(c *gin.Context) {
+	var opts repository.PostListOptions
+	if c.Param("id") != "" {
+		userID, ok := parseIDParam(c, "id", "user")
+		if !ok {
+			return
+		}
+		opts.UserID = userID
+	}
+
+	page, err := parsePage(c)
+	if err == nil {
+		opts.IncludeUser, err = parseIncludeUser(c)
+	}
+	if err != nil {
+		utils.LogValidationError(c, "query", c.Request.URL.RawQuery, err, logrus.Fields{
+			"operation": "list_posts",
+		})
+		respondValidationError(c, err)
+		return
+	}
+	opts.Page = page
+
+	list, err := h.repo.List(c.Request.Context(), opts)
+	if err != nil {
+		respondUserLookupError(c, "list_posts", opts.UserID, err, "Failed to list posts")
+		return
+	}
+
+	// Always render an array, never null
+	posts := list.Posts
+	if posts == nil {
+		posts = []models.Post{}
+	}
+
+	c.JSON(http.StatusOK, ListResponse{
+		Data:   posts,
+		Total:  list.Total,
+		Limit:  page.Limit,
+		Offset: &page.Offset,
+		Links:  pageLinks(c, page, list.HasMore),
+	})
+}
+
+// Update handles updating a post's title and content
+func (h *PostHandler) Update(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	postID, ok := parseIDParam(c, "id", "post")
+	if !ok {
+		return
+	}
+
+	var changes models.Post
+	if err := c.ShouldBindJSON(&changes); err != nil {
+		utils.LogValidationError(c, "post_data", changes, err, logrus.Fields{
+			"operation": "update_post",
+			"post_id":   postID,
+		})
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
+		})
+		return
+	}
+	if err := validatePost(&changes, true); err != nil {
+		utils.LogValidationError(c, "post_data", changes, err)
+		respondValidationError(c, err)
+		return
+	}
+
+	post, err := h.repo.Update(c.Request.Context(), postID, &changes)
+	if err != nil {
+		respondPostLookupError(c, "update_post", postID, err, "Failed to update post")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "update_post",
+		"post_id":    post.ID,
+	}).Info("Post updated successfully")
+
+	c.JSON(http.StatusOK, post)
+}
+
+// Delete handles soft-deleting a post
+func (h *PostHandler) Delete(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	postID, ok := parseIDParam(c, "id", "post")
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), postID); err != nil {
+		respondPostLookupError(c, "delete_post", postID, err, "Failed to delete post")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "delete_post",
+		"post_id":    postID,
+	}).Info("Post deleted successfully")
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+	"github.com/yourname/employee-api/utils"
+	"github.com/yourname/employee-api/validation"
+)
+
+// UserHandler serves the user endpoints
+type UserHandler struct {
+	repo repository.UserRepository
+}
+
+// NewUserHandler creates a user handler backed by the given repository
+func NewUserHandler(repo repository.UserRepository) *UserHandler {
+	return &UserHandler{repo: repo}
+}
+
+// validateUser normalizes and checks a user body. A partial body, as sent to
+// update, may leave fields empty to keep their current values.
+func validateUser(user *models.User, partial bool) error {
+	user.Name = strings.TrimSpace(user.Name)
+	user.Email = strings.ToLower(strings.TrimSpace(user.Email))
+	if partial {
+		return validation.Partial(user)
+	}
+	return validation.Struct(user)
+}
+
+// respondUserLookupError writes the response for a failed user lookup
+func respondUserLookupError(c *gin.Context, operation string, id uint, err error, failure string) {
+	respondDBError(c, operation, err, "User not found", failure, logrus.Fields{
+		"user_id": id,
+	})
+}
+
+// respondEmailConflict writes the response for a write rejected by the unique email index
+func respondEmailConflict(c *gin.Context, operation string, email string, err error) {
+	utils.LogBusinessError(c, operation, err, logrus.Fields{
+		"user_email": email,
+	})
+	middleware.RespondError(c, http.StatusConflict, middleware.ErrorResponse{
+		Error: "A user with this email already exists",
+		Code:  utils.ErrCodeDuplicate,
+	})
+}
+
+// bindUser binds and validates a user body. On failure it writes a 400 response and returns false.
+func bindUser(c *gin.Context, operation string, partial bool) (*models.User, bool) {
+	var user models.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		utils.LogValidationError(c, "user_data", user, err, logrus.Fields{
+			"operation": operation,
+		})
+		middleware.RespondError(c, http.StatusBadRequest, middleware.ErrorResponse{
+			Error: "Invalid request format",
+			Code:  middleware.CodeInvalidRequest,
+		})
+		return nil, false
+	}
+	if err := validateUser(&user, partial); err != nil {
+		utils.LogValidationError(c, "user_data", user, err, logrus.Fields{
+			"operation": operation,
+		})
+		respondValidationError(c, err)
+		return nil, false
+	}
+	return &user, true
+}
+
+// Create handles the creation of a new user
+func (h *UserHandler) Create(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	user, ok := bindUser(c, "create_user", false)
+	if !ok {
+		return
+	}
+
+	err := h.repo.Create(c.Request.Context(), user)
+	if errors.Is(err, repository.ErrDuplicate) {
+		respondEmailConflict(c, "create_user", user.Email, err)
+		return
+	}
+	if err != nil {
+		respondDBError(c, "create_user", err, "", "Failed to create user")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "create_user",
+		"user_id":    user.ID,
+	}).Info("User created successfully")
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// Get handles retrieving a user by ID
+func (h *UserHandler) Get(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	user, err := h.repo.Get(c.Request.Context(), userID)
+	if err != nil {
+		respondUserLookupError(c, "get_user", userID, err, "Failed to retrieve user")
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// List handles listing users with pagination
+func (h *UserHandler) List(c *gin.Context) {
+	page, err := parsePage(c)
+	if err != nil {
+		utils.LogValidationError(c, "query", c.Request.URL.RawQuery, err, logrus.Fields{
+			"operation": "list_users",
+		})
+		respondValidationError(c, err)
+		return
+	}
+
+	list, err := h.repo.List(c.Request.Context(), page)
+	if err != nil {
+		respondDBError(c, "list_users", err, "", "Failed to list users")
+		return
+	}
+
+	// Always render an array, never null
+	users := list.Users
+	if users == nil {
+		users = []models.User{}
+	}
+
+	c.JSON(http.StatusOK, ListResponse{
+		Data:   users,
+		Total:  list.Total,
+		Limit:  page.Limit,
+		Offset: &page.Offset,
+		Links:  pageLinks(c, page, list.HasMore),
+	})
+}
+
+// Update handles updating a user
+func (h *UserHandler) Update(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	userID, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	changes, ok := bindUser(c, "update_user", true)
+	if !ok {
+		return
+	}
+
+	user, err := h.repo.Update(c.Request.Context(), userID, changes)
+	if errors.Is(err, repository.ErrDuplicate) {
+		respondEmailConflict(c, "update_user", changes.Email, err)
+		return
+	}
+	if err != nil {
+		respondUserLookupError(c, "update_user", userID, err, "Failed to update user")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "update_user",
+		"user_id":    user.ID,
+	}).Info("User updated successfully")
+
+	c.JSON(http.StatusOK, user)
+}
+
+// Delete handles soft-deleting a user
+func (h *UserHandler) Delete(c *gin.Context) {
+	logger := utils.GetLogger()
+	requestID := middleware.GetRequestID(c)
+
+	userID, ok := parseIDParam(c, "id", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), userID); err != nil {
+		respondUserLookupError(c, "delete_user", userID, err, "Failed to delete user")
+		return
+	}
+
+	logger.WithFields(logrus.Fields{
+		"request_id": requestID,
+		"operation":  "delete_user",
+		"user_id":    userID,
+	}).Info("User deleted successfully")
+
+	c.Status(http.StatusNoContent)
+}
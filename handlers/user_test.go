@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/middleware"
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/repository"
+)
+
+// setupUserPostRouter creates a test router serving the user and post routes from in-memory repositories
+func setupUserPostRouter() *gin.Engine {
+	userRepo := repository.NewMemoryUserRepository()
+	users := NewUserHandler(userRepo)
+	posts := NewPostHandler(repository.NewMemoryPostRepository(userRepo))
+
+	router := setupTestRouter()
+	router.GET("/users", users.List)
+	router.POST("/users", users.Create)
+	router.GET("/users/:id", users.Get)
+	router.PUT("/users/:id", users.Update)
+	router.DELETE("/users/:id", users.Delete)
+	router.GET("/posts", posts.List)
+	router.POST("/posts", posts.Create)
+	router.GET("/posts/:id", posts.Get)
+	router.PUT("/posts/:id", posts.Update)
+	router.DELETE("/posts/:id", posts.Delete)
+	router.GET("/users/:id/posts", posts.List)
+	router.POST("/users/:id/posts", posts.Create)
+
+	return router
+}
+
+// createUser creates a user through the API and returns it
+func createUser(t *testing.T, router *gin.Engine, name, email string) models.User {
+	t.Helper()
+	w := performRequest(router, http.MethodPost, "/users", []byte(fmt.Sprintf(`{"name":%q,"email":%q}`, name, email)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var user models.User
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	return user
+}
+
+func TestUserHandler_CRUD(t *testing.T) {
+	router := setupUserPostRouter()
+
+	user := createUser(t, router, "Ada Lovelace", " Ada@Example.com ")
+	assert.Equal(t, "ada@example.com", user.Email)
+
+	w := performRequest(router, http.MethodPut, fmt.Sprintf("/users/%d", user.ID), []byte(`{"name":"Ada King"}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Ada King"`)
+	assert.Contains(t, w.Body.String(), `"email":"ada@example.com"`)
+
+	w = performRequest(router, http.MethodGet, "/users?limit=1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	w = performRequest(router, http.MethodDelete, fmt.Sprintf("/users/%d", user.ID), nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = performRequest(router, http.MethodGet, fmt.Sprintf("/users/%d", user.ID), nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUserHandler_EmailConflict(t *testing.T) {
+	router := setupUserPostRouter()
+	createUser(t, router, "Ada", "ada@example.com")
+	grace := createUser(t, router, "Grace", "grace@example.com")
+
+	w := performRequest(router, http.MethodPost, "/users", []byte(`{"name":"Other Ada","email":"ADA@example.com"}`))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"error":"A user with this email already exists","code":"duplicate"}`, w.Body.String())
+
+	w = performRequest(router, http.MethodPut, fmt.Sprintf("/users/%d", grace.ID), []byte(`{"email":"ada@example.com"}`))
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestUserHandler_Validation(t *testing.T) {
+	router := setupUserPostRouter()
+
+	tests := []struct {
+		name  string
+		body  string
+		error string
+	}{
+		{name: "missing name", body: `{"email":"a@example.com"}`, error: "name is required"},
+		{name: "missing email", body: `{"name":"A"}`, error: "email is required"},
+		{name: "invalid email", body: `{"name":"A","email":"not-an-email"}`, error: "email must be a valid email address"},
+		{name: "display name", body: `{"name":"A","email":"A <a@example.com>"}`, error: "email must be a valid email address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := performRequest(router, http.MethodPost, "/users", []byte(tt.body))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, fmt.Sprintf(`{"error":%q,"code":"validation_failed"}`, tt.error), w.Body.String())
+		})
+	}
+}
+
+func TestPostHandler_NestedAndInclude(t *testing.T) {
+	router := setupUserPostRouter()
+	ada := createUser(t, router, "Ada", "ada@example.com")
+	grace := createUser(t, router, "Grace", "grace@example.com")
+
+	w := performRequest(router, http.MethodPost, fmt.Sprintf("/users/%d/posts", ada.ID), []byte(`{"title":"Notes on the Engine","content":"..."}`))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var post models.Post
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &post))
+	assert.Equal(t, ada.ID, post.UserID)
+
+	w = performRequest(router, http.MethodPost, "/posts", []byte(fmt.Sprintf(`{"title":"COBOL","user_id":%d}`, grace.ID)))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// The author is only embedded on request
+	w = performRequest(router, http.MethodGet, fmt.Sprintf("/posts/%d", post.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"user":`)
+
+	w = performRequest(router, http.MethodGet, fmt.Sprintf("/posts/%d?include=user", post.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"email":"ada@example.com"`)
+
+	// Nested listing only returns the user's posts
+	w = performRequest(router, http.MethodGet, fmt.Sprintf("/users/%d/posts?include=user", grace.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data  []models.Post `json:"data"`
+		Total int64         `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "COBOL", list.Data[0].Title)
+	require.NotNil(t, list.Data[0].User)
+	assert.Equal(t, "grace@example.com", list.Data[0].User.Email)
+
+	w = performRequest(router, http.MethodGet, "/posts?include=comments", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPostHandler_Errors(t *testing.T) {
+	router := setupUserPostRouter()
+	ada := createUser(t, router, "Ada", "ada@example.com")
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		error  string
+		code   string
+	}{
+		{name: "missing title", method: http.MethodPost, path: "/posts", body: fmt.Sprintf(`{"user_id":%d}`, ada.ID), status: http.StatusBadRequest, error: "title is required", code: "validation_failed"},
+		{name: "missing author", method: http.MethodPost, path: "/posts", body: `{"title":"x"}`, status: http.StatusBadRequest, error: "user_id is required", code: "validation_failed"},
+		{name: "unknown author", method: http.MethodPost, path: "/users/99/posts", body: `{"title":"x"}`, status: http.StatusNotFound, error: "User not found", code: "not_found"},
+		{name: "unknown user listing", method: http.MethodGet, path: "/users/99/posts", status: http.StatusNotFound, error: "User not found", code: "not_found"},
+		{name: "unknown post", method: http.MethodPut, path: "/posts/99", body: `{"title":"x"}`, status: http.StatusNotFound, error: "Post not found", code: "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			if tt.body != "" {
+				body = []byte(tt.body)
+			}
+			w := performRequest(router, tt.method, tt.path, body)
+			assert.Equal(t, tt.status, w.Code)
+			expected, err := json.Marshal(middleware.ErrorResponse{Error: tt.error, Code: tt.code})
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), w.Body.String())
+		})
+	}
+}
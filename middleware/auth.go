@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/auth"
+	"github.com/yourname/employee-api/utils"
+)
+
+// Gin context keys holding the authenticated caller
+const (
+	SubjectKey = "auth_subject"
+	ClaimsKey  = "auth_claims"
+)
+
+// TokenVerifier validates credentials and returns their principal
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*auth.Principal, error)
+}
+
+// Authorization schemes accepted by AuthenticateSchemes
+const (
+	SchemeBearer = "Bearer"
+	SchemeAPIKey = "ApiKey"
+)
+
+// Authenticate is a middleware that requires a valid bearer token. The token's
+// subject and claims are placed on the context for handlers and the request logger.
+func Authenticate(verifier TokenVerifier, logger *logrus.Logger) gin.HandlerFunc {
+	return AuthenticateSchemes(map[string]TokenVerifier{SchemeBearer: verifier}, logger)
+}
+
+// AuthenticateSchemes is like Authenticate but accepts several Authorization
+// schemes, such as "Bearer <jwt>" and "ApiKey <key>", each with its own verifier
+func AuthenticateSchemes(verifiers map[string]TokenVerifier, logger *logrus.Logger) gin.HandlerFunc {
+	bySchemes := make(map[string]TokenVerifier, len(verifiers))
+	challenges := make([]string, 0, len(verifiers))
+	for scheme, verifier := range verifiers {
+		bySchemes[strings.ToLower(scheme)] = verifier
+		challenges = append(challenges, scheme+` realm="employee-api"`)
+	}
+	sort.Strings(challenges)
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		scheme, token, ok := credentials(c.GetHeader("Authorization"))
+		verifier := bySchemes[strings.ToLower(scheme)]
+		if !ok || verifier == nil {
+			for _, challenge := range challenges {
+				c.Writer.Header().Add("WWW-Authenticate", challenge)
+			}
+			AbortWithErrorResponse(c, http.StatusUnauthorized, ErrorResponse{
+				Error: "Authentication required",
+				Code:  CodeUnauthorized,
+			})
+			return
+		}
+
+		principal, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil && !rejectsCredentials(err) {
+			logger.WithFields(logrus.Fields{
+				"request_id": GetRequestID(c),
+				"path":       c.Request.URL.Path,
+				"method":     c.Request.Method,
+				"scheme":     scheme,
+				"error":      err.Error(),
+			}).Error("Failed to verify credentials")
+
+			// The credentials may be fine; answer like any other server-side failure
+			if translated := utils.TranslateDBError(err); translated.Retryable {
+				c.Header("Retry-After", "1")
+				AbortWithErrorResponse(c, translated.Status, ErrorResponse{
+					Error: translated.Message,
+					Code:  translated.Code,
+				})
+				return
+			}
+			AbortWithErrorResponse(c, http.StatusInternalServerError, ErrorResponse{
+				Error: "Failed to verify credentials",
+				Code:  utils.ErrCodeInternal,
+			})
+			return
+		}
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"request_id": GetRequestID(c),
+				"path":       c.Request.URL.Path,
+				"method":     c.Request.Method,
+				"scheme":     scheme,
+				"error":      err.Error(),
+			}).Warn("Credentials rejected")
+
+			message := tokenErrorMessage(err)
+			c.Header("WWW-Authenticate", scheme+` realm="employee-api", error="invalid_token", error_description="`+message+`"`)
+			AbortWithErrorResponse(c, http.StatusUnauthorized, ErrorResponse{
+				Error: message,
+				Code:  CodeUnauthorized,
+			})
+			return
+		}
+
+		c.Set(SubjectKey, principal.Subject)
+		c.Set(ClaimsKey, principal.Claims)
+		c.Next()
+	})
+}
+
+// GetSubject returns the authenticated subject, or an empty string
+func GetSubject(c *gin.Context) string {
+	return c.GetString(SubjectKey)
+}
+
+// GetClaims returns the authenticated token's claims, or nil
+func GetClaims(c *gin.Context) jwt.MapClaims {
+	if claims, exists := c.Get(ClaimsKey); exists {
+		if mapClaims, ok := claims.(jwt.MapClaims); ok {
+			return mapClaims
+		}
+	}
+	return nil
+}
+
+// credentials splits an "Authorization: <scheme> <token>" header
+func credentials(header string) (scheme, token string, ok bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found {
+		return "", "", false
+	}
+	token = strings.TrimSpace(token)
+	return scheme, token, token != ""
+}
+
+// rejectsCredentials reports whether a verification error means the presented
+// credentials are invalid, rather than that they could not be checked
+func rejectsCredentials(err error) bool {
+	for _, target := range []error{
+		auth.ErrTokenExpired, auth.ErrTokenNotYet, auth.ErrTokenAudience, auth.ErrTokenIssuer, auth.ErrTokenInvalid,
+		auth.ErrAPIKeyMalformed, auth.ErrAPIKeyUnknown, auth.ErrAPIKeyInactive,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenErrorMessage describes a verification failure without leaking parser details
+func tokenErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, auth.ErrTokenExpired):
+		return "Token has expired"
+	case errors.Is(err, auth.ErrTokenNotYet):
+		return "Token is not valid yet"
+	case errors.Is(err, auth.ErrTokenAudience):
+		return "Token audience is not accepted"
+	case errors.Is(err, auth.ErrTokenIssuer):
+		return "Token issuer is not accepted"
+	case errors.Is(err, auth.ErrAPIKeyInactive):
+		return "API key is revoked or expired"
+	case errors.Is(err, auth.ErrAPIKeyMalformed), errors.Is(err, auth.ErrAPIKeyUnknown):
+		return "Invalid API key"
+	default:
+		return "Invalid token"
+	}
+}
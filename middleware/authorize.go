@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/auth"
+)
+
+// Gin context keys holding what the authorized caller may do and see
+const (
+	PermissionsKey            = "auth_permissions"
+	HiddenFieldsKey           = "auth_hidden_fields"
+	HiddenFieldPermissionsKey = "auth_hidden_field_permissions"
+)
+
+// Authorize is a middleware that checks the authenticated caller's roles and
+// scopes against the policy for the matched route. It must run after Authenticate.
+func Authorize(policy *auth.Policy, logger *logrus.Logger) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		principal := &auth.Principal{Subject: GetSubject(c), Claims: GetClaims(c)}
+		granted := policy.Grants(principal.Roles(), principal.Scopes())
+
+		if err := policy.Authorize(c.Request.Method, c.FullPath(), granted); err != nil {
+			logger.WithFields(logrus.Fields{
+				"request_id": GetRequestID(c),
+				"path":       c.Request.URL.Path,
+				"method":     c.Request.Method,
+				"subject":    principal.Subject,
+				"error":      err.Error(),
+			}).Warn("Access denied")
+
+			message := "Access denied"
+			var permissionErr *auth.PermissionError
+			if errors.As(err, &permissionErr) {
+				message = "Permission " + string(permissionErr.Permission) + " required"
+			}
+			AbortWithErrorResponse(c, http.StatusForbidden, ErrorResponse{
+				Error: message,
+				Code:  CodeForbidden,
+			})
+			return
+		}
+
+		c.Set(PermissionsKey, granted)
+		if hidden := policy.HiddenFields(granted); len(hidden) > 0 {
+			required := make(map[string]auth.Permission, len(hidden))
+			for _, field := range hidden {
+				required[field] = policy.Fields[field]
+			}
+			c.Set(HiddenFieldsKey, hidden)
+			c.Set(HiddenFieldPermissionsKey, required)
+		}
+		c.Next()
+	})
+}
+
+// GetPermissions returns the permissions granted to the caller, or nil when
+// the route is not behind Authorize
+func GetPermissions(c *gin.Context) auth.PermissionSet {
+	if granted, exists := c.Get(PermissionsKey); exists {
+		if permissions, ok := granted.(auth.PermissionSet); ok {
+			return permissions
+		}
+	}
+	return nil
+}
+
+// RespondHiddenFieldsWritten writes the 403 response for a request that sets
+// fields hidden from the caller, naming the permission the first one requires.
+// Problem details list every such field, prefixed with where the request sets it.
+func RespondHiddenFieldsWritten(c *gin.Context, prefix string, fields []string) {
+	required, _ := c.Get(HiddenFieldPermissionsKey)
+	permissions, _ := required.(map[string]auth.Permission)
+
+	params := make([]InvalidParam, len(fields))
+	for i, field := range fields {
+		params[i] = InvalidParam{Name: prefix + field, Reason: "requires permission " + string(permissions[field])}
+	}
+	AbortWithErrorResponse(c, http.StatusForbidden, ErrorResponse{
+		Error:         "Permission " + string(permissions[fields[0]]) + " required",
+		Code:          CodeForbidden,
+		InvalidParams: params,
+	})
+}
+
+// RestrictedJSON renders obj as JSON without the fields Authorize hid from the caller
+func RestrictedJSON(c *gin.Context, statusCode int, obj interface{}) {
+	hidden := c.GetStringSlice(HiddenFieldsKey)
+	if len(hidden) == 0 {
+		c.JSON(statusCode, obj)
+		return
+	}
+
+	// Round-trip through a generic value so fields can be removed at any depth
+	data, err := json.Marshal(obj)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(statusCode, removeFields(generic, hidden))
+}
+
+// removeFields deletes the named keys from every object in a decoded JSON value
+func removeFields(value interface{}, fields []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, field := range fields {
+			delete(v, field)
+		}
+		for key, item := range v {
+			v[key] = removeFields(item, fields)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = removeFields(item, fields)
+		}
+	}
+	return value
+}
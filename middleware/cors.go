@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORS headers advertised to browsers
+const (
+	corsAllowMethods  = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowHeaders  = "Authorization, Content-Type, If-Match, If-None-Match, X-Request-ID, " + IdempotencyKeyHeader
+	corsExposeHeaders = "ETag, X-Request-ID, " + IdempotentReplayedHeader
+	corsMaxAge        = "600"
+)
+
+// CORS is a middleware that allows cross-origin requests from the origins
+// returned by allowedOrigins, which is consulted on every request so the list
+// can change at runtime. "*" allows any origin.
+func CORS(allowedOrigins func() []string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		if !originAllowed(origin, allowedOrigins()) {
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Expose-Headers", corsExposeHeaders)
+
+		// Answer preflight requests without reaching the handlers
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", corsAllowMethods)
+			c.Header("Access-Control-Allow-Headers", corsAllowHeaders)
+			c.Header("Access-Control-Max-Age", corsMaxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// originAllowed reports whether origin matches the allow list
+func originAllowed(origin string, allowed []string) bool {
+	for _, candidate := range allowed {
+		if candidate == "*" || strings.EqualFold(candidate, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,11 +5,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/utils"
 )
 
-// ErrorResponse represents a standardized error response
+// ErrorResponse represents a standardized error response. It is the legacy
+// body; clients accepting application/problem+json get a Problem instead.
 type ErrorResponse struct {
 	Error string `json:"error"`
+	// Code is a machine-readable error code, e.g. "duplicate"
+	Code string `json:"code,omitempty"`
+	// InvalidParams lists per-field errors; only problem details include them
+	InvalidParams []InvalidParam `json:"-"`
 }
 
 // ErrorHandler is a middleware that handles errors and provides structured error responses
@@ -37,22 +44,40 @@ func ErrorHandler(logger *logrus.Logger) gin.HandlerFunc {
 			if !c.Writer.Written() {
 				var statusCode int
 				var errorMessage string
+				var errorCode string
 
 				// Determine status code based on error type
-				switch err.Type {
-				case gin.ErrorTypeBind:
+				translated := utils.TranslateDBError(err.Err)
+				switch {
+				case err.Type == gin.ErrorTypeBind:
 					statusCode = http.StatusBadRequest
 					errorMessage = "Invalid request format"
-				case gin.ErrorTypePublic:
+					errorCode = CodeInvalidRequest
+				case err.Type == gin.ErrorTypePublic:
 					statusCode = http.StatusBadRequest
 					errorMessage = err.Error()
+					errorCode = CodeBadRequest
+				case translated.Code == utils.ErrCodeNotFound:
+					statusCode = translated.Status
+					errorMessage = "Resource not found"
+					errorCode = translated.Code
+				case translated.Code != utils.ErrCodeInternal:
+					// Database errors the client caused or may retry
+					statusCode = translated.Status
+					errorMessage = translated.Message
+					errorCode = translated.Code
+					if translated.Retryable {
+						c.Header("Retry-After", "1")
+					}
 				default:
 					statusCode = http.StatusInternalServerError
 					errorMessage = "Internal server error"
+					errorCode = utils.ErrCodeInternal
 				}
 
-				c.JSON(statusCode, ErrorResponse{
+				RespondError(c, statusCode, ErrorResponse{
 					Error: errorMessage,
+					Code:  errorCode,
 				})
 			}
 		}
@@ -73,8 +98,12 @@ func AbortWithError(c *gin.Context, statusCode int, err error, logger *logrus.Lo
 	}).Error("Request aborted with error")
 
 	// Return standardized error response
-	c.JSON(statusCode, ErrorResponse{
+	code := CodeBadRequest
+	if statusCode >= http.StatusInternalServerError {
+		code = utils.ErrCodeInternal
+	}
+	AbortWithErrorResponse(c, statusCode, ErrorResponse{
 		Error: err.Error(),
+		Code:  code,
 	})
-	c.Abort()
 }
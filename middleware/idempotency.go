@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// IdempotencyKeyHeader names the header clients send to make a request safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marks a response that was replayed from an earlier request
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength bounds the accepted key, which is stored verbatim
+const maxIdempotencyKeyLength = 255
+
+// idempotencyLockTimeout is how long a key stays reserved by a request that
+// neither completed nor released it, e.g. because the server crashed
+const idempotencyLockTimeout = time.Minute
+
+// replayedHeaders are the response headers stored and replayed with the body
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotencyStore persists Idempotency-Key records
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, record *models.IdempotencyKey) error
+	Release(ctx context.Context, subject, key string) error
+}
+
+// Idempotency is a middleware that makes requests carrying an Idempotency-Key
+// header safe to retry. The first request with a key runs and its response is
+// stored for ttl; repeats of the same request get the stored response back.
+// Reusing a key for a different request, or while the first one is still
+// running, is a 409. Server errors are not stored so the request can be retried.
+func Idempotency(store IdempotencyStore, ttl time.Duration, logger *logrus.Logger) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			AbortWithErrorResponse(c, http.StatusBadRequest, ErrorResponse{
+				Error: "Idempotency-Key must be at most 255 characters",
+				Code:  CodeInvalidRequest,
+				InvalidParams: []InvalidParam{
+					{Name: IdempotencyKeyHeader, Reason: "must be at most 255 characters"},
+				},
+			})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		record := &models.IdempotencyKey{
+			Subject:     GetSubject(c),
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			Fingerprint: requestFingerprint(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(idempotencyLockTimeout),
+		}
+		fields := logrus.Fields{
+			"request_id":      GetRequestID(c),
+			"path":            record.Path,
+			"method":          record.Method,
+			"idempotency_key": key,
+		}
+
+		stored, reserved, err := store.Reserve(c.Request.Context(), record)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		if !reserved {
+			replay(c, stored, record, fields, logger)
+			return
+		}
+
+		// The outcome is saved even if the client has gone away, and a panic
+		// in a handler releases the key
+		ctx := context.WithoutCancel(c.Request.Context())
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := store.Release(ctx, record.Subject, record.Key); err != nil {
+				logger.WithFields(fields).WithError(err).Error("Failed to release idempotency key")
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Errors left for ErrorHandler have not been written yet
+		if !recorder.Written() || recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		record.StatusCode = recorder.Status()
+		record.Header = http.Header{}
+		for _, name := range replayedHeaders {
+			if values := recorder.Header().Values(name); len(values) > 0 {
+				record.Header[name] = values
+			}
+		}
+		record.Body = recorder.body.Bytes()
+		record.ExpiresAt = time.Now().Add(ttl)
+		if err := store.Complete(ctx, record); err != nil {
+			logger.WithFields(fields).WithError(err).Error("Failed to store idempotent response")
+			return
+		}
+		completed = true
+	})
+}
+
+// replay answers a request whose key is already reserved: with the stored
+// response if it is a repeat of the completed request, otherwise with a 409
+func replay(c *gin.Context, stored, record *models.IdempotencyKey, fields logrus.Fields, logger *logrus.Logger) {
+	if stored.Fingerprint != record.Fingerprint {
+		logger.WithFields(fields).Warn("Idempotency key reused for a different request")
+		AbortWithErrorResponse(c, http.StatusConflict, ErrorResponse{
+			Error: "Idempotency-Key was already used for a different request",
+			Code:  CodeIdempotencyKeyReused,
+		})
+		return
+	}
+	if !stored.Completed() {
+		logger.WithFields(fields).Warn("Idempotency key is in use by a request in progress")
+		c.Header("Retry-After", "1")
+		AbortWithErrorResponse(c, http.StatusConflict, ErrorResponse{
+			Error: "A request with this Idempotency-Key is still in progress",
+			Code:  CodeIdempotencyInProgress,
+		})
+		return
+	}
+
+	logger.WithFields(fields).Info("Replaying idempotent response")
+	for name, values := range stored.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Abort()
+	c.Data(stored.StatusCode, stored.Header.Get("Content-Type"), stored.Body)
+}
+
+// requestFingerprint hashes what makes two requests the same request
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.Path+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder passes a response through while keeping a copy of its body
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write records and writes a chunk of the body
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString records and writes a chunk of the body
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -6,19 +6,24 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/utils"
 )
 
 // Logger returns a gin.HandlerFunc (middleware) that logs requests using logrus.
 func Logger(logger *logrus.Logger) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		// Generate request ID if not present
-		requestID := param.Request.Header.Get("X-Request-ID")
+		// Prefer the ID assigned by RequestID so access and SQL logs correlate
+		requestID := utils.RequestIDFromContext(param.Request.Context())
+		if requestID == "" {
+			requestID = param.Request.Header.Get("X-Request-ID")
+		}
 		if requestID == "" {
 			requestID = uuid.New().String()
 		}
 
 		// Log with structured fields
-		logger.WithFields(logrus.Fields{
+		fields := logrus.Fields{
 			"request_id":    requestID,
 			"timestamp":     param.TimeStamp.Format(time.RFC3339),
 			"status":        param.StatusCode,
@@ -28,7 +33,11 @@ func Logger(logger *logrus.Logger) gin.HandlerFunc {
 			"path":          param.Path,
 			"user_agent":    param.Request.UserAgent(),
 			"error_message": param.ErrorMessage,
-		}).Info("HTTP Request")
+		}
+		if subject, ok := param.Keys[SubjectKey].(string); ok && subject != "" {
+			fields["subject"] = subject
+		}
+		logger.WithFields(fields).Info("HTTP Request")
 
 		return ""
 	})
@@ -42,9 +51,11 @@ func RequestID() gin.HandlerFunc {
 			requestID = uuid.New().String()
 		}
 
-		// Set the request ID in both the response header and context
+		// Set the request ID in the response header, the Gin context and the
+		// request context so repositories and the SQL logger can see it
 		c.Header("X-Request-ID", requestID)
 		c.Set("request_id", requestID)
+		c.Request = c.Request.WithContext(utils.ContextWithRequestID(c.Request.Context(), requestID))
 		c.Next()
 	})
 }
@@ -0,0 +1,400 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/auth"
+	"github.com/yourname/employee-api/repository"
+)
+
+// newTestRouter returns a router with a single GET /ping route behind the middleware
+func newTestRouter(middleware ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware...)
+	router.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	return router
+}
+
+// silentLogger discards log output
+func silentLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestCORS(t *testing.T) {
+	origins := []string{"https://app.example.com"}
+	router := newTestRouter(CORS(func() []string { return origins }))
+
+	t.Run("allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/ping", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodPost)
+	})
+
+	t.Run("origin list changes at runtime", func(t *testing.T) {
+		origins = nil
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	limits := RateLimitConfig{PerSecond: 1, Burst: 2}
+	router := newTestRouter(RateLimit(func() RateLimitConfig { return limits }, silentLogger()))
+
+	get := func() int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		return w.Code
+	}
+
+	// Burst is spent, then requests are rejected
+	assert.Equal(t, http.StatusOK, get())
+	assert.Equal(t, http.StatusOK, get())
+	assert.Equal(t, http.StatusTooManyRequests, get())
+
+	// New limits take effect immediately with fresh buckets
+	limits = RateLimitConfig{PerSecond: 1, Burst: 3}
+	assert.Equal(t, http.StatusOK, get())
+
+	// Zero disables limiting
+	limits = RateLimitConfig{}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusOK, get())
+	}
+}
+
+// stubVerifier accepts a single token
+type stubVerifier struct {
+	token string
+	err   error
+}
+
+func (s stubVerifier) Verify(_ context.Context, token string) (*auth.Principal, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if token != s.token {
+		return nil, auth.ErrTokenInvalid
+	}
+	return &auth.Principal{Subject: "user-42", Claims: jwt.MapClaims{"sub": "user-42"}}, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serve := func(verifier TokenVerifier, header string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(Authenticate(verifier, silentLogger()))
+		router.GET("/whoami", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"subject": GetSubject(c), "claims": GetClaims(c)})
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		w := serve(stubVerifier{token: "good"}, "Bearer good")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"subject":"user-42","claims":{"sub":"user-42"}}`, w.Body.String())
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		w := serve(stubVerifier{token: "good"}, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"Authentication required","code":"unauthorized"}`, w.Body.String())
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+	})
+
+	t.Run("wrong scheme", func(t *testing.T) {
+		w := serve(stubVerifier{token: "good"}, "Basic good")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		w := serve(stubVerifier{err: auth.ErrTokenExpired}, "Bearer old")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"Token has expired","code":"unauthorized"}`, w.Body.String())
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), `error="invalid_token"`)
+	})
+
+	t.Run("verifier failure", func(t *testing.T) {
+		w := serve(stubVerifier{err: assert.AnError}, "Bearer good")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, `{"error":"Failed to verify credentials","code":"internal_error"}`, w.Body.String())
+		assert.Empty(t, w.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		w := serve(stubVerifier{err: auth.ErrTokenAudience}, "Bearer other")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"Token audience is not accepted","code":"unauthorized"}`, w.Body.String())
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policy := &auth.Policy{
+		Roles:  map[string][]auth.Permission{"viewer": {"records:read"}, "hr": {"records:read", "records:sensitive"}},
+		Rules:  []auth.Rule{{Method: http.MethodGet, Route: "/records/:id", Permission: "records:read"}},
+		Fields: map[string]auth.Permission{"salary": "records:sensitive"},
+	}
+	serve := func(claims jwt.MapClaims, path string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(func(c *gin.Context) { c.Set(ClaimsKey, claims) }, Authorize(policy, silentLogger()))
+		handler := func(c *gin.Context) {
+			RestrictedJSON(c, http.StatusOK, gin.H{"data": []gin.H{{"id": 1, "salary": 100}}})
+		}
+		router.GET("/records/:id", handler)
+		router.GET("/unmapped", handler)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("permitted with sensitive fields", func(t *testing.T) {
+		w := serve(jwt.MapClaims{"roles": []interface{}{"hr"}}, "/records/1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":[{"id":1,"salary":100}]}`, w.Body.String())
+	})
+
+	t.Run("permitted with hidden fields", func(t *testing.T) {
+		w := serve(jwt.MapClaims{"roles": "viewer"}, "/records/1")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":[{"id":1}]}`, w.Body.String())
+	})
+
+	t.Run("missing permission", func(t *testing.T) {
+		w := serve(jwt.MapClaims{}, "/records/1")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error":"Permission records:read required","code":"forbidden"}`, w.Body.String())
+	})
+
+	t.Run("unmapped route", func(t *testing.T) {
+		w := serve(jwt.MapClaims{"roles": "hr"}, "/unmapped")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error":"Access denied","code":"forbidden"}`, w.Body.String())
+	})
+}
+
+func TestErrorHandler_ContentNegotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("request_id", "req-1")
+		c.Next()
+	})
+	router.Use(ErrorHandler(silentLogger()))
+	router.GET("/fail", func(c *gin.Context) {
+		_ = c.Error(errors.New("boom"))
+	})
+	serve := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, accept := range []string{"", "*/*", "application/json"} {
+		t.Run("legacy for "+accept, func(t *testing.T) {
+			w := serve(accept)
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+			assert.JSONEq(t, `{"error":"Internal server error","code":"internal_error"}`, w.Body.String())
+		})
+	}
+
+	t.Run("problem details", func(t *testing.T) {
+		w := serve("application/problem+json, application/json")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"type": "urn:employee-api:problem:internal_error",
+			"title": "Internal Server Error",
+			"status": 500,
+			"detail": "Internal server error",
+			"instance": "req-1",
+			"code": "internal_error"
+		}`, w.Body.String())
+	})
+}
+
+func TestAbortWithErrorResponse_Problem(t *testing.T) {
+	router := newTestRouter(func(c *gin.Context) {
+		AbortWithErrorResponse(c, http.StatusBadRequest, ErrorResponse{
+			Error:         "age must be a positive integer",
+			Code:          CodeValidationFailed,
+			InvalidParams: []InvalidParam{{Name: "age", Reason: "must be a positive integer"}},
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("Accept", ProblemContentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"type": "urn:employee-api:problem:validation_failed",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "age must be a positive integer",
+		"code": "validation_failed",
+		"invalid-params": [{"name": "age", "reason": "must be a positive integer"}]
+	}`, w.Body.String())
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := repository.NewMemoryIdempotencyRepository()
+	var created, failures atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+
+	router := gin.New()
+	router.Use(ErrorHandler(silentLogger()))
+	router.Use(func(c *gin.Context) {
+		c.Set(SubjectKey, c.GetHeader("X-Subject"))
+		c.Next()
+	})
+	router.Use(Idempotency(store, time.Hour, silentLogger()))
+	router.POST("/items", func(c *gin.Context) {
+		id := created.Add(1)
+		c.Header("Location", "/items/"+strconv.Itoa(int(id)))
+		c.JSON(http.StatusCreated, gin.H{"id": id})
+	})
+	router.POST("/failing", func(c *gin.Context) {
+		if failures.Add(1) == 1 {
+			_ = c.Error(errors.New("boom"))
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+	router.POST("/slow", func(c *gin.Context) {
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	post := func(path, key, subject, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		req.Header.Set("X-Subject", subject)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("repeat replays the stored response", func(t *testing.T) {
+		first := post("/items", "key-1", "alice", `{"name":"a"}`)
+		require.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+		second := post("/items", "key-1", "alice", `{"name":"a"}`)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, first.Header().Get("Location"), second.Header().Get("Location"))
+		assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, int32(1), created.Load())
+	})
+
+	t.Run("different body is a conflict", func(t *testing.T) {
+		w := post("/items", "key-1", "alice", `{"name":"b"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"error":"Idempotency-Key was already used for a different request","code":"idempotency_key_reused"}`, w.Body.String())
+	})
+
+	t.Run("keys are scoped to the caller", func(t *testing.T) {
+		w := post("/items", "key-1", "bob", `{"name":"b"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, int32(2), created.Load())
+	})
+
+	t.Run("requests without a key are not deduplicated", func(t *testing.T) {
+		post("/items", "", "alice", `{}`)
+		post("/items", "", "alice", `{}`)
+		assert.Equal(t, int32(4), created.Load())
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		w := post("/items", strings.Repeat("k", 256), "alice", `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, int32(4), created.Load())
+	})
+
+	t.Run("server errors are not stored", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, post("/failing", "key-2", "alice", `{}`).Code)
+		assert.Equal(t, http.StatusCreated, post("/failing", "key-2", "alice", `{}`).Code)
+		assert.Equal(t, int32(2), failures.Load())
+	})
+
+	t.Run("concurrent duplicate while in flight", func(t *testing.T) {
+		done := make(chan *httptest.ResponseRecorder)
+		go func() { done <- post("/slow", "key-3", "alice", `{}`) }()
+		<-started
+
+		w := post("/slow", "key-3", "alice", `{}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"error":"A request with this Idempotency-Key is still in progress","code":"idempotency_in_progress"}`, w.Body.String())
+
+		close(release)
+		assert.Equal(t, http.StatusCreated, (<-done).Code)
+		assert.Equal(t, "true", post("/slow", "key-3", "alice", `{}`).Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("expired records are replaced", func(t *testing.T) {
+		deleted, err := store.DeleteExpired(context.Background(), time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), deleted)
+
+		assert.Equal(t, http.StatusCreated, post("/items", "key-1", "alice", `{"name":"b"}`).Code)
+	})
+}
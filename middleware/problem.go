@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix namespaces the type URIs of problems that carry an error code
+const problemTypePrefix = "urn:employee-api:problem:"
+
+// Machine-readable codes of request errors
+const (
+	// CodeInvalidRequest marks a body that could not be parsed
+	CodeInvalidRequest = "invalid_request"
+	// CodeValidationFailed marks a well-formed request with invalid values
+	CodeValidationFailed = "validation_failed"
+	// CodeBadRequest marks other errors reported to the client as is
+	CodeBadRequest = "bad_request"
+	// CodeUnauthorized marks a request without valid credentials
+	CodeUnauthorized = "unauthorized"
+	// CodeForbidden marks credentials that lack a permission the request needs
+	CodeForbidden = "forbidden"
+	// CodeRateLimited marks a request over the client's rate limit
+	CodeRateLimited = "rate_limited"
+	// CodeNotDeleted marks a restore of a resource that is not deleted
+	CodeNotDeleted = "not_deleted"
+	// CodeAPIKeyRevoked marks an operation on a revoked API key
+	CodeAPIKeyRevoked = "api_key_revoked"
+	// CodeUnsupportedMediaType marks a body in a format the endpoint does not accept
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	// CodeNotAcceptable marks an Accept header naming no format the endpoint produces
+	CodeNotAcceptable = "not_acceptable"
+	// CodePayloadTooLarge marks a body over the size the endpoint accepts
+	CodePayloadTooLarge = "payload_too_large"
+	// CodePatchConflict marks a patch that does not fit the current resource
+	CodePatchConflict = "patch_conflict"
+	// CodePreconditionFailed marks an If-Match header naming an outdated version
+	CodePreconditionFailed = "precondition_failed"
+	// CodeVersionConflict marks a write that raced with another update
+	CodeVersionConflict = "version_conflict"
+	// CodeResourceInUse marks a delete of a resource others still refer to
+	CodeResourceInUse = "resource_in_use"
+	// CodeIdempotencyKeyReused marks an Idempotency-Key sent with a different request
+	CodeIdempotencyKeyReused = "idempotency_key_reused"
+	// CodeIdempotencyInProgress marks a retry that arrived before the original request finished
+	CodeIdempotencyInProgress = "idempotency_in_progress"
+)
+
+// InvalidParam describes one invalid request field
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Problem is an RFC 7807 problem details object
+type Problem struct {
+	// Type identifies the kind of problem; "about:blank" when it has no code
+	Type string `json:"type"`
+	// Title is a short summary of the status
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Detail explains this occurrence
+	Detail string `json:"detail,omitempty"`
+	// Instance is the ID of the failed request
+	Instance      string         `json:"instance,omitempty"`
+	Code          string         `json:"code,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// NewProblem builds the problem details of an error response
+func NewProblem(c *gin.Context, status int, response ErrorResponse) Problem {
+	problemType := "about:blank"
+	if response.Code != "" {
+		problemType = problemTypePrefix + response.Code
+	}
+	return Problem{
+		Type:          problemType,
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        response.Error,
+		Instance:      GetRequestID(c),
+		Code:          response.Code,
+		InvalidParams: response.InvalidParams,
+	}
+}
+
+// wantsProblem reports whether the client prefers problem details. Clients
+// that do not ask for application/problem+json get the legacy ErrorResponse.
+func wantsProblem(c *gin.Context) bool {
+	return c.NegotiateFormat(binding.MIMEJSON, ProblemContentType) == ProblemContentType
+}
+
+// RespondError writes an error response in the format the client negotiated
+func RespondError(c *gin.Context, status int, response ErrorResponse) {
+	if !wantsProblem(c) {
+		c.JSON(status, response)
+		return
+	}
+	// The JSON renderer keeps a content type that is already set
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(status, NewProblem(c, status, response))
+}
+
+// AbortWithErrorResponse writes an error response and stops the handler chain
+func AbortWithErrorResponse(c *gin.Context, status int, response ErrorResponse) {
+	c.Abort()
+	RespondError(c, status, response)
+}
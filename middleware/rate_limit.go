@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// Idle clients are forgotten after limiterIdleTTL, checked every limiterSweepInterval
+const (
+	limiterIdleTTL       = 3 * time.Minute
+	limiterSweepInterval = time.Minute
+)
+
+// RateLimitConfig holds per-client rate limit settings
+type RateLimitConfig struct {
+	// PerSecond is the sustained request rate; zero disables limiting
+	PerSecond int
+	Burst     int
+}
+
+// clientLimiter tracks one client's token bucket
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter keeps a token bucket per client IP
+type rateLimiter struct {
+	mu        sync.Mutex
+	config    RateLimitConfig
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// RateLimit is a middleware that limits requests per client IP. The limits
+// are read on every request so they can change at runtime; a change resets
+// all buckets.
+func RateLimit(limits func() RateLimitConfig, logger *logrus.Logger) gin.HandlerFunc {
+	rl := &rateLimiter{clients: map[string]*clientLimiter{}}
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		config := limits()
+		if config.PerSecond <= 0 {
+			c.Next()
+			return
+		}
+
+		if !rl.allow(c.ClientIP(), config, time.Now()) {
+			logger.WithFields(logrus.Fields{
+				"request_id": GetRequestID(c),
+				"client_ip":  c.ClientIP(),
+				"path":       c.Request.URL.Path,
+				"method":     c.Request.Method,
+			}).Warn("Rate limit exceeded")
+
+			// With at least one token per second the next one is never more than a second away
+			c.Header("Retry-After", "1")
+			AbortWithErrorResponse(c, http.StatusTooManyRequests, ErrorResponse{
+				Error: "Rate limit exceeded",
+				Code:  CodeRateLimited,
+			})
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// allow takes a token from the client's bucket
+func (rl *rateLimiter) allow(client string, config RateLimitConfig, now time.Time) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// New limits apply to everyone from a fresh bucket
+	if config != rl.config {
+		rl.config = config
+		rl.clients = map[string]*clientLimiter{}
+	}
+
+	if now.Sub(rl.lastSweep) > limiterSweepInterval {
+		for ip, entry := range rl.clients {
+			if now.Sub(entry.lastSeen) > limiterIdleTTL {
+				delete(rl.clients, ip)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	entry, ok := rl.clients[client]
+	if !ok {
+		entry = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(config.PerSecond), config.Burst)}
+		rl.clients[client] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_employees_deleted_at;
+ALTER TABLE employees DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,2 @@
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_employees_deleted_at ON employees (deleted_at);
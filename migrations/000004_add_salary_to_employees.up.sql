@@ -0,0 +1 @@
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS salary NUMERIC(12, 2);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_employees_employee_number;
+ALTER TABLE employees DROP COLUMN IF EXISTS employee_number;
@@ -0,0 +1,3 @@
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS employee_number TEXT NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_employees_employee_number ON employees (employee_number)
+  WHERE employee_number <> '' AND deleted_at IS NULL;
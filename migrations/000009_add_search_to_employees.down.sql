@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_employees_last_name_trgm;
+DROP INDEX IF EXISTS idx_employees_first_name_trgm;
+DROP INDEX IF EXISTS idx_employees_search_vector;
+ALTER TABLE employees DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,6 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS search_vector tsvector
+  GENERATED ALWAYS AS (to_tsvector('simple', first_name || ' ' || last_name)) STORED;
+CREATE INDEX IF NOT EXISTS idx_employees_search_vector ON employees USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_employees_first_name_trgm ON employees USING GIN (first_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_employees_last_name_trgm ON employees USING GIN (last_name gin_trgm_ops);
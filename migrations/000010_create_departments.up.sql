@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS departments (
+  id BIGSERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  deleted_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_departments_name ON departments (name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_departments_deleted_at ON departments (deleted_at);
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS department_id BIGINT
+  CONSTRAINT fk_employees_department REFERENCES departments (id);
+CREATE INDEX IF NOT EXISTS idx_employees_department_id ON employees (department_id);
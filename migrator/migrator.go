@@ -0,0 +1,404 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yourname/employee-api/utils"
+)
+
+// advisoryLockKey identifies the Postgres advisory lock that serializes
+// migrations across replicas. It is "employee" in ASCII.
+const advisoryLockKey int64 = 0x656d706c6f796565
+
+var (
+	// ErrDirty is returned when a legacy golang-migrate table records a failed migration
+	ErrDirty = errors.New("database is in a dirty migration state")
+
+	// ErrChecksumMismatch is returned when an applied migration file was edited afterwards
+	ErrChecksumMismatch = errors.New("applied migration does not match its file")
+)
+
+// Status describes whether a single migration has been applied
+type Status struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// Modified reports that the file changed after the migration was applied
+	Modified bool
+}
+
+// appliedMigration is a row of schema_migrations
+type appliedMigration struct {
+	Version   uint
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Migrator applies SQL migrations and records every applied version with
+// its checksum in schema_migrations. All operations hold a Postgres advisory
+// lock so that replicas starting at the same time do not race.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New creates a migrator for the migrations found in fsys
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations returns the known migrations ordered by version
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Status reports which known migrations are applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]Status, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if row, ok := applied[migration.Version]; ok {
+				appliedAt := row.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = row.Checksum != migration.Checksum()
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Up applies up to n pending migrations; n <= 0 applies all of them
+func (m *Migrator) Up(ctx context.Context, n int) (int, error) {
+	applied := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := rows[migration.Version]; ok {
+				continue
+			}
+			if n > 0 && applied >= n {
+				break
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back up to n applied migrations; n <= 0 rolls back all of them
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	reverted := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := rows[migration.Version]; !ok {
+				continue
+			}
+			if n > 0 && reverted >= n {
+				break
+			}
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			reverted++
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Goto applies or rolls back migrations until exactly the versions up to
+// and including version are applied
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		// Roll back newer migrations first, newest to oldest
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := rows[migration.Version]; ok && migration.Version > version {
+				if err := m.revert(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Then apply anything missing, oldest to newest
+		for _, migration := range m.migrations {
+			if _, ok := rows[migration.Version]; !ok && migration.Version <= version {
+				if err := m.apply(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// known reports whether a migration with the given version exists
+func (m *Migrator) known(version uint) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	logger := utils.GetLogger().WithField("operation", "migrate")
+	logger.Debug("Waiting for migration lock")
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Unlock even if ctx was canceled so the lock does not outlive the call
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey); err != nil {
+			logger.WithError(err).Error("Failed to release migration lock")
+		}
+	}()
+
+	if err := m.prepare(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// prepare creates schema_migrations, converting a golang-migrate table if one exists
+func (m *Migrator) prepare(ctx context.Context, conn *sql.Conn) error {
+	var legacy bool
+	err := conn.QueryRowContext(ctx, `SELECT EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		  AND table_name = 'schema_migrations'
+		  AND column_name = 'dirty'
+	)`).Scan(&legacy)
+	if err != nil {
+		return fmt.Errorf("inspect schema_migrations: %w", err)
+	}
+
+	if legacy {
+		return m.convertLegacy(ctx, conn)
+	}
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// convertLegacy replaces golang-migrate's single-row table with one row per
+// applied migration. Every known migration up to the recorded version is
+// considered applied.
+func (m *Migrator) convertLegacy(ctx context.Context, conn *sql.Conn) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin schema_migrations conversion: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err = tx.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("read legacy schema version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("%w at version %d: fix the schema manually and reset schema_migrations", ErrDirty, version)
+	}
+
+	statements := []string{
+		`ALTER TABLE schema_migrations RENAME TO schema_migrations_legacy`,
+		`CREATE TABLE schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("convert schema_migrations: %w", err)
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if int64(migration.Version) > version {
+			break
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)`,
+			int64(migration.Version), migration.Checksum()); err != nil {
+			return fmt.Errorf("convert schema_migrations: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DROP TABLE schema_migrations_legacy`); err != nil {
+		return fmt.Errorf("convert schema_migrations: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit schema_migrations conversion: %w", err)
+	}
+
+	utils.GetLogger().WithFields(logrus.Fields{
+		"operation":      "migrate",
+		"legacy_version": version,
+	}).Info("Converted golang-migrate schema_migrations table")
+	return nil
+}
+
+// applied returns the rows of schema_migrations keyed by version
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[uint]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[uint]appliedMigration)
+	for rows.Next() {
+		var (
+			row     appliedMigration
+			version int64
+		)
+		if err := rows.Scan(&version, &row.Checksum, &row.AppliedAt); err != nil {
+			return nil, fmt.Errorf("read schema_migrations: %w", err)
+		}
+		row.Version = uint(version)
+		applied[row.Version] = row
+	}
+	return applied, rows.Err()
+}
+
+// verifiedApplied returns the applied migrations after checking none of their files changed
+func (m *Migrator) verifiedApplied(ctx context.Context, conn *sql.Conn) (map[uint]appliedMigration, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []uint
+	for _, migration := range m.migrations {
+		if row, ok := applied[migration.Version]; ok && row.Checksum != migration.Checksum() {
+			modified = append(modified, migration.Version)
+		}
+	}
+	if len(modified) > 0 {
+		sort.Slice(modified, func(i, j int) bool { return modified[i] < modified[j] })
+		return nil, fmt.Errorf("%w: versions %v", ErrChecksumMismatch, modified)
+	}
+
+	return applied, nil
+}
+
+// apply runs a migration's up script and records it in one transaction
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	return m.run(ctx, conn, migration, "up", migration.Up,
+		`INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)`,
+		int64(migration.Version), migration.Checksum())
+}
+
+// revert runs a migration's down script and removes its record in one transaction
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+	}
+	return m.run(ctx, conn, migration, "down", migration.Down,
+		`DELETE FROM schema_migrations WHERE version = $1`,
+		int64(migration.Version))
+}
+
+// run executes a migration script followed by the bookkeeping statement
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, migration Migration, direction, script, record string, args ...interface{}) error {
+	logger := utils.GetLogger().WithFields(logrus.Fields{
+		"operation": "migrate",
+		"migration": fmt.Sprintf("%d_%s", migration.Version, migration.Name),
+		"direction": direction,
+	})
+	logger.Info("Applying migration")
+	start := time.Now()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin migration %d: %w", migration.Version, err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("run migration %d_%s %s: %w", migration.Version, migration.Name, direction, err)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("record migration %d: %w", migration.Version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit migration %d: %w", migration.Version, err)
+	}
+
+	logger.WithField("duration", time.Since(start)).Info("Migration applied")
+	return nil
+}
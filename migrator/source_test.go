@@ -0,0 +1,71 @@
+package migrator
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourname/employee-api/migrations"
+)
+
+func TestLoad_OrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_column.up.sql":   {Data: []byte("ALTER TABLE t ADD COLUMN c INT;")},
+		"000002_add_column.down.sql": {Data: []byte("ALTER TABLE t DROP COLUMN c;")},
+		"000001_create.up.sql":       {Data: []byte("CREATE TABLE t (id INT);")},
+		"000001_create.down.sql":     {Data: []byte("DROP TABLE t;")},
+		"README.md":                  {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, uint(1), migrations[0].Version)
+	assert.Equal(t, "create", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE t;", migrations[0].Down)
+	assert.Equal(t, uint(2), migrations[1].Version)
+	assert.Equal(t, "add_column", migrations[1].Name)
+}
+
+func TestLoad_RequiresUpFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_create.down.sql": {Data: []byte("DROP TABLE t;")},
+	}
+
+	_, err := Load(fsys)
+	assert.EqualError(t, err, "migration 1_create has no up file")
+}
+
+func TestLoad_RejectsConflictingNames(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_create.up.sql": {Data: []byte("CREATE TABLE t (id INT);")},
+		"000001_other.up.sql":  {Data: []byte("CREATE TABLE u (id INT);")},
+	}
+
+	_, err := Load(fsys)
+	assert.Error(t, err)
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	loaded, err := Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for _, m := range loaded {
+		assert.NotEmpty(t, m.Up, "migration %d has an empty up script", m.Version)
+		assert.NotEmpty(t, m.Down, "migration %d has an empty down script", m.Version)
+	}
+}
+
+func TestMigration_ChecksumTracksUpScript(t *testing.T) {
+	original := Migration{Version: 1, Up: "CREATE TABLE t (id INT);", Down: "DROP TABLE t;"}
+	downChanged := Migration{Version: 1, Up: "CREATE TABLE t (id INT);", Down: "DROP TABLE IF EXISTS t;"}
+	upChanged := Migration{Version: 1, Up: "CREATE TABLE t (id BIGINT);", Down: "DROP TABLE t;"}
+
+	assert.Len(t, original.Checksum(), 64)
+	assert.Equal(t, original.Checksum(), downChanged.Checksum())
+	assert.NotEqual(t, original.Checksum(), upChanged.Checksum())
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey is a service-to-service credential owned by a User. Only a hash of
+// the key is stored; the prefix identifies it in listings and lookups.
+type APIKey struct {
+	Base
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	User       User       `gorm:"foreignKey:UserID" json:"-"`
+	Name       string     `gorm:"not null" json:"name"`
+	Prefix     string     `gorm:"uniqueIndex;not null" json:"prefix"`
+	Hash       string     `gorm:"not null" json:"-"`
+	Scopes     string     `gorm:"not null;default:''" json:"-"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// ScopeList returns the key's space separated scopes as a list
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+// Active reports whether the key is neither revoked nor expired at the given time
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
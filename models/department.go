@@ -0,0 +1,9 @@
+package models
+
+// Department groups employees; an employee belongs to at most one
+type Department struct {
+	Base
+	// Name is unique among current departments
+	Name        string `gorm:"not null" json:"name" validate:"required,max=255"`
+	Description string `gorm:"not null;default:''" json:"description" validate:"max=1000"`
+}
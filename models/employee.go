@@ -1,12 +1,18 @@
 package models
 
-import "time"
-
 // Employee represents an employee in the system
 type Employee struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	FirstName string    `json:"first_name" validate:"required"`
-	LastName  string    `json:"last_name" validate:"required"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Base
+	// EmployeeNumber is an optional identifier from HR systems, unique among
+	// current employees; imports use it to match rows to employees
+	EmployeeNumber string `gorm:"not null;default:''" json:"employee_number,omitempty" validate:"omitempty,max=64,employee_number"`
+	FirstName      string `json:"first_name" validate:"required,max=255,name"`
+	LastName       string `json:"last_name" validate:"required,max=255,name"`
+	// DepartmentID names the department of the employee, if any
+	DepartmentID *uint `gorm:"index" json:"department_id,omitempty" validate:"omitempty,min=1"`
+	// Salary is the yearly salary; responses only include it for callers
+	// allowed to read sensitive fields
+	Salary *float64 `gorm:"type:numeric(12,2)" json:"salary,omitempty" validate:"omitempty,min=0"`
+	// Version increases with every update; it backs the ETag of the employee
+	Version uint `gorm:"not null;default:1" json:"version"`
 }
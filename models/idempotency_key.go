@@ -0,0 +1,29 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyKey records a request sent with an Idempotency-Key header and,
+// once it has completed, the response replayed to retries. Keys belong to the
+// caller that sent them, so different callers may use the same key.
+type IdempotencyKey struct {
+	Subject string `gorm:"primaryKey"`
+	Key     string `gorm:"primaryKey"`
+	Method  string `gorm:"not null"`
+	Path    string `gorm:"not null"`
+	// Fingerprint is a hash of the method, path and body of the request
+	Fingerprint string `gorm:"not null"`
+	// StatusCode is zero while the request is still being processed
+	StatusCode int         `gorm:"not null;default:0"`
+	Header     http.Header `gorm:"type:jsonb;serializer:json"`
+	Body       []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time `gorm:"not null;index"`
+}
+
+// Completed reports whether the response of the request has been stored
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
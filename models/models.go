@@ -16,24 +16,31 @@ type Base struct {
 // User represents a user in the system
 type User struct {
 	Base
-	Name  string `gorm:"not null" json:"name"`
-	Email string `gorm:"uniqueIndex;not null" json:"email"`
+	Name  string `gorm:"not null" json:"name" validate:"required,max=255"`
+	Email string `gorm:"uniqueIndex;not null" json:"email" validate:"required,email"`
 }
 
 // Post represents a blog post or article
 type Post struct {
 	Base
-	Title   string `gorm:"not null" json:"title"`
+	Title   string `gorm:"not null" json:"title" validate:"required,max=255"`
 	Content string `gorm:"type:text" json:"content"`
-	UserID  uint   `gorm:"not null" json:"user_id"`
-	User    User   `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	UserID  uint   `gorm:"not null" json:"user_id" validate:"required"`
+	// User is only loaded on request
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
 
-// AutoMigrate runs database migrations for all models
+// AutoMigrate runs database migrations for all models.
+//
+// Deprecated: the schema is owned by the SQL files in migrations/, which the
+// migrator package applies. AutoMigrate is kept for throwaway databases only.
 func AutoMigrate(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&User{},
 		&Post{},
+		&Department{},
 		&Employee{},
+		&APIKey{},
+		&IdempotencyKey{},
 	)
 }
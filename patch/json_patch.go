@@ -0,0 +1,326 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Operation is one step of a JSON Patch
+type Operation struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+	From string `json:"from,omitempty"`
+	// Value is nil when the member is absent and "null" when it is null
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch applies a JSON Patch, a list of operations run in order, to a
+// document. Either every operation applies or the document is left unchanged.
+func JSONPatch(doc, jsonPatch []byte) ([]byte, error) {
+	var operations []Operation
+	if err := json.Unmarshal(jsonPatch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	for i, operation := range operations {
+		if target, err = operation.apply(target); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+// JSONPatchMembers returns the top-level document members a JSON Patch reads
+// or writes, or nil for a patch that does not parse. An operation on the
+// whole document touches the members of its value.
+func JSONPatchMembers(jsonPatch []byte) []string {
+	var operations []Operation
+	if err := json.Unmarshal(jsonPatch, &operations); err != nil {
+		return nil
+	}
+
+	var members []string
+	for _, operation := range operations {
+		for _, pointer := range []string{operation.Path, operation.From} {
+			if path, err := parsePointer(pointer); err == nil && len(path) > 0 {
+				members = append(members, path[0])
+			}
+		}
+		if operation.Path == "" {
+			members = append(members, MergePatchMembers(operation.Value)...)
+		}
+	}
+	return members
+}
+
+// apply runs the operation on a decoded document and returns the new document
+func (o Operation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case "add", "replace", "test":
+		value, err := o.value()
+		if err != nil {
+			return nil, err
+		}
+		switch o.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			return replace(doc, path, value)
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("%w: test failed at %q", ErrCannotApply, o.Path)
+			}
+			return doc, nil
+		}
+
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return doc, err
+
+	case "move", "copy":
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if o.Op == "copy" {
+			return add(doc, path, deepCopy(value))
+		}
+		if o.Path != o.From && strings.HasPrefix(o.Path, o.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move %q into itself", ErrInvalidPatch, o.From)
+		}
+		if doc, _, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, o.Op)
+	}
+}
+
+// value decodes the operation's value, which is required for add, replace and test
+func (o Operation) value() (interface{}, error) {
+	if o.Value == nil {
+		return nil, fmt.Errorf("%w: %s requires a value", ErrInvalidPatch, o.Op)
+	}
+	value, err := decode(o.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return value, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// get returns the value a path refers to
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := doc.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, missing(path)
+			}
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = container[index]
+		default:
+			return nil, missing(path)
+		}
+	}
+	return doc, nil
+}
+
+// add inserts a value; an existing object member is replaced and array
+// elements shift right. "-" appends to an array.
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	return update(doc, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			index := len(container)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(container)); err != nil {
+					return nil, err
+				}
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		default:
+			return nil, missing(path)
+		}
+	}, value)
+}
+
+// replace sets the value of an existing location
+func replace(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	return update(doc, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, missing(path)
+			}
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			container[index] = value
+			return container, nil
+		default:
+			return nil, missing(path)
+		}
+	}, value)
+}
+
+// remove deletes an existing location and returns the removed value
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+
+	var removed interface{}
+	doc, err := update(doc, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, missing(path)
+			}
+			removed = value
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = container[index]
+			return append(container[:index:index], container[index+1:]...), nil
+		default:
+			return nil, missing(path)
+		}
+	}, nil)
+	return doc, removed, err
+}
+
+// update walks to the parent of a path and lets change modify it. Arrays may
+// be reallocated, so every container on the way is rebuilt from its result.
+// An empty path replaces the whole document with root.
+func update(doc interface{}, path []string, change func(container interface{}, token string) (interface{}, error), root interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return root, nil
+	}
+	if len(path) == 1 {
+		return change(doc, path[0])
+	}
+
+	child, err := get(doc, path[:1])
+	if err != nil {
+		return nil, missing(path)
+	}
+	child, err = update(child, path[1:], change, root)
+	if err != nil {
+		return nil, err
+	}
+
+	switch container := doc.(type) {
+	case map[string]interface{}:
+		container[path[0]] = child
+	case []interface{}:
+		index, _ := arrayIndex(path[0], len(container)-1)
+		container[index] = child
+	}
+	return doc, nil
+}
+
+// arrayIndex parses an array reference token no greater than max
+func arrayIndex(token string, max int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	if index > max {
+		return 0, fmt.Errorf("%w: array index %d is out of range", ErrCannotApply, index)
+	}
+	return index, nil
+}
+
+// missing reports a path that does not exist in the document
+func missing(path []string) error {
+	return fmt.Errorf("%w: path %q does not exist", ErrCannotApply, formatPointer(path))
+}
+
+// formatPointer joins reference tokens back into a JSON Pointer
+func formatPointer(path []string) string {
+	var pointer strings.Builder
+	for _, token := range path {
+		pointer.WriteString("/")
+		pointer.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return pointer.String()
+}
+
+// deepCopy copies a decoded JSON value so copies do not share containers
+func deepCopy(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for name, member := range value {
+			copied[name] = deepCopy(member)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, element := range value {
+			copied[i] = deepCopy(element)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,86 @@
+// Package patch applies JSON Merge Patch (RFC 7396) and JSON Patch
+// (RFC 6902) documents to JSON documents.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Media types of the supported patch formats
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+// ErrInvalidPatch is returned for malformed patch documents
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// ErrCannotApply is returned for well-formed patches that do not fit the
+// document, e.g. a path that does not exist or a failed test operation
+var ErrCannotApply = errors.New("patch cannot be applied")
+
+// MergePatch applies a JSON Merge Patch to a document: members of the patch
+// replace those of the document, objects merge recursively and null removes.
+func MergePatch(doc, mergePatch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := decode(mergePatch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(merge(target, changes))
+}
+
+// MergePatchMembers returns the top-level document members a JSON Merge Patch
+// changes, or nil for a patch that is not an object
+func MergePatchMembers(mergePatch []byte) []string {
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(mergePatch, &changes); err != nil {
+		return nil
+	}
+	members := make([]string, 0, len(changes))
+	for name := range changes {
+		members = append(members, name)
+	}
+	return members
+}
+
+// merge implements the MergePatch algorithm of RFC 7396 on decoded values
+func merge(target, changes interface{}) interface{} {
+	changesObject, ok := changes.(map[string]interface{})
+	if !ok {
+		return changes
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for name, value := range changesObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = merge(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// decode parses a JSON value, keeping numbers exact
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return value, nil
+}
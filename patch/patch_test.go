@@ -0,0 +1,102 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396, appendix A
+	tests := []struct {
+		doc, patch, result string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		result, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+		require.NoError(t, err)
+		assert.JSONEq(t, tt.result, string(result), "%s + %s", tt.doc, tt.patch)
+	}
+
+	_, err := MergePatch([]byte(`{}`), []byte(`{"a":`))
+	assert.ErrorIs(t, err, ErrInvalidPatch)
+}
+
+func TestJSONPatch(t *testing.T) {
+	// Mostly examples from RFC 6902, appendix A
+	tests := []struct {
+		name, doc, patch, result string
+	}{
+		{"add member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"append element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc"]}]`, `{"foo":["bar",["abc"]]}`},
+		{"remove member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move member", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"move element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"copy", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`, `{"a":{"b":1},"c":{"b":2}}`},
+		{"test passes", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"add null", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":null}]`, `{"foo":"bar","baz":null}`},
+		{"escaped tokens", `{"/":9,"~1":10}`, `[{"op":"replace","path":"/~1","value":1},{"op":"remove","path":"/~01"}]`, `{"/":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := JSONPatch([]byte(tt.doc), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.result, string(result))
+		})
+	}
+}
+
+func TestJSONPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name, patch string
+		err         error
+	}{
+		{"not a list", `{"op":"add"}`, ErrInvalidPatch},
+		{"unknown op", `[{"op":"frobnicate","path":"/a"}]`, ErrInvalidPatch},
+		{"missing value", `[{"op":"add","path":"/a"}]`, ErrInvalidPatch},
+		{"relative path", `[{"op":"remove","path":"a"}]`, ErrInvalidPatch},
+		{"move into itself", `[{"op":"move","from":"/a","path":"/a/b"}]`, ErrInvalidPatch},
+		{"missing member", `[{"op":"replace","path":"/nope","value":1}]`, ErrCannotApply},
+		{"missing parent", `[{"op":"add","path":"/nope/x","value":1}]`, ErrCannotApply},
+		{"index out of range", `[{"op":"add","path":"/list/5","value":1}]`, ErrCannotApply},
+		{"test fails", `[{"op":"test","path":"/a","value":2}]`, ErrCannotApply},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := JSONPatch([]byte(`{"a":{"b":1},"list":[1]}`), []byte(tt.patch))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPatchMembers(t *testing.T) {
+	assert.ElementsMatch(t, []string{"a", "b"}, MergePatchMembers([]byte(`{"a":1,"b":{"c":null}}`)))
+	assert.Empty(t, MergePatchMembers([]byte(`["a"]`)))
+
+	assert.Equal(t, []string{"a", "c", "b", "x/y", "~z", "d"}, JSONPatchMembers([]byte(`[
+		{"op":"replace","path":"/a/0","value":1},
+		{"op":"copy","from":"/b","path":"/c"},
+		{"op":"test","path":"/x~1y","value":1},
+		{"op":"remove","path":"/~0z"},
+		{"op":"replace","path":"","value":{"d":1}}
+	]`)))
+	assert.Empty(t, JSONPatchMembers([]byte(`{"op":"add"}`)))
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// ErrRevoked is returned when rotating an API key that was already revoked
+var ErrRevoked = errors.New("API key is revoked")
+
+// APIKeyRepository abstracts API key persistence
+type APIKeyRepository interface {
+	// Create stores a new key; it returns ErrNotFound when the user does not exist
+	Create(ctx context.Context, key *models.APIKey) error
+	// List returns every key of a user, revoked ones included, newest first
+	List(ctx context.Context, userID uint) ([]models.APIKey, error)
+	// Get returns one key of a user
+	Get(ctx context.Context, userID, id uint) (*models.APIKey, error)
+	// Rotate revokes an active key and stores its replacement in one step
+	Rotate(ctx context.Context, userID, id uint, replacement *models.APIKey, at time.Time) error
+	// Revoke marks a key revoked; revoking a revoked key is a no-op
+	Revoke(ctx context.Context, userID, id uint, at time.Time) (*models.APIKey, error)
+	// FindAPIKeyByPrefix returns a key with its owning user loaded
+	FindAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	// MarkAPIKeyUsed records when a key was last used
+	MarkAPIKeyUsed(ctx context.Context, id uint, at time.Time) error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// GormAPIKeyRepository is the PostgreSQL-backed APIKeyRepository
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAPIKeyRepository creates a repository on top of a GORM connection
+func NewGormAPIKeyRepository(db *gorm.DB) *GormAPIKeyRepository {
+	return &GormAPIKeyRepository{db: db}
+}
+
+// userExists returns ErrNotFound unless the user exists and is not deleted
+func userExists(db *gorm.DB, userID uint) error {
+	return db.Select("id").First(&models.User{}, userID).Error
+}
+
+// Create stores a new key
+func (r *GormAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := userExists(tx, key.UserID); err != nil {
+			return err
+		}
+		return tx.Omit("User").Create(key).Error
+	})
+}
+
+// List returns every key of a user
+func (r *GormAPIKeyRepository) List(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	db := r.db.WithContext(ctx)
+	if err := userExists(db, userID); err != nil {
+		return nil, err
+	}
+
+	var keys []models.APIKey
+	if err := db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Get returns one key of a user
+func (r *GormAPIKeyRepository) Get(ctx context.Context, userID, id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Rotate revokes an active key and stores its replacement
+func (r *GormAPIKeyRepository) Rotate(ctx context.Context, userID, id uint, replacement *models.APIKey, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var key models.APIKey
+		if err := tx.Where("user_id = ?", userID).First(&key, id).Error; err != nil {
+			return err
+		}
+		if key.RevokedAt != nil {
+			return ErrRevoked
+		}
+
+		// Only one rotation may win when two race for the same key
+		result := tx.Model(&key).Where("revoked_at IS NULL").Update("revoked_at", at)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRevoked
+		}
+		return tx.Omit("User").Create(replacement).Error
+	})
+}
+
+// Revoke marks a key revoked
+func (r *GormAPIKeyRepository) Revoke(ctx context.Context, userID, id uint, at time.Time) (*models.APIKey, error) {
+	db := r.db.WithContext(ctx)
+	if err := db.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at).Error; err != nil {
+		return nil, err
+	}
+	return r.Get(ctx, userID, id)
+}
+
+// FindAPIKeyByPrefix returns a key with its owning user loaded
+func (r *GormAPIKeyRepository) FindAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).Preload("User").Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// MarkAPIKeyUsed records when a key was last used
+func (r *GormAPIKeyRepository) MarkAPIKeyUsed(ctx context.Context, id uint, at time.Time) error {
+	// UpdateColumn leaves updated_at alone; usage is not a modification
+	return r.db.WithContext(ctx).Model(&models.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
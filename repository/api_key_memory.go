@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// MemoryAPIKeyRepository is an in-memory APIKeyRepository for tests and local development
+type MemoryAPIKeyRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]models.User
+	keys   map[uint]models.APIKey
+	nextID uint
+	now    func() time.Time
+}
+
+// NewMemoryAPIKeyRepository creates an empty repository that knows the given users
+func NewMemoryAPIKeyRepository(users ...models.User) *MemoryAPIKeyRepository {
+	r := &MemoryAPIKeyRepository{
+		users:  make(map[uint]models.User),
+		keys:   make(map[uint]models.APIKey),
+		nextID: 1,
+		now:    time.Now,
+	}
+	for _, user := range users {
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+// Create stores a new key
+func (r *MemoryAPIKeyRepository) Create(_ context.Context, key *models.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[key.UserID]; !ok {
+		return ErrNotFound
+	}
+	r.insert(key)
+	return nil
+}
+
+// insert assigns an ID and timestamps and stores the key; callers hold the lock
+func (r *MemoryAPIKeyRepository) insert(key *models.APIKey) {
+	now := r.now()
+	key.ID = r.nextID
+	key.CreatedAt = now
+	key.UpdatedAt = now
+	r.nextID++
+	r.keys[key.ID] = *key
+}
+
+// List returns every key of a user
+func (r *MemoryAPIKeyRepository) List(_ context.Context, userID uint) ([]models.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.users[userID]; !ok {
+		return nil, ErrNotFound
+	}
+
+	keys := []models.APIKey{}
+	for _, key := range r.keys {
+		if key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID > keys[j].ID })
+	return keys, nil
+}
+
+// Get returns one key of a user
+func (r *MemoryAPIKeyRepository) Get(_ context.Context, userID, id uint) (*models.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.UserID != userID {
+		return nil, ErrNotFound
+	}
+	return &key, nil
+}
+
+// Rotate revokes an active key and stores its replacement
+func (r *MemoryAPIKeyRepository) Rotate(_ context.Context, userID, id uint, replacement *models.APIKey, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.UserID != userID {
+		return ErrNotFound
+	}
+	if key.RevokedAt != nil {
+		return ErrRevoked
+	}
+
+	key.RevokedAt = &at
+	key.UpdatedAt = r.now()
+	r.keys[id] = key
+	r.insert(replacement)
+	return nil
+}
+
+// Revoke marks a key revoked
+func (r *MemoryAPIKeyRepository) Revoke(_ context.Context, userID, id uint, at time.Time) (*models.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.UserID != userID {
+		return nil, ErrNotFound
+	}
+	if key.RevokedAt == nil {
+		key.RevokedAt = &at
+		key.UpdatedAt = r.now()
+		r.keys[id] = key
+	}
+	return &key, nil
+}
+
+// FindAPIKeyByPrefix returns a key with its owning user loaded
+func (r *MemoryAPIKeyRepository) FindAPIKeyByPrefix(_ context.Context, prefix string) (*models.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Prefix == prefix {
+			key.User = r.users[key.UserID]
+			return &key, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// MarkAPIKeyUsed records when a key was last used
+func (r *MemoryAPIKeyRepository) MarkAPIKeyUsed(_ context.Context, id uint, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return ErrNotFound
+	}
+	key.LastUsedAt = &at
+	r.keys[id] = key
+	return nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// DepartmentList is one page of departments
+type DepartmentList struct {
+	Departments []models.Department
+	// Total counts every current department, ignoring paging
+	Total int64
+	// HasMore reports whether more rows exist past the page
+	HasMore bool
+}
+
+// DepartmentRepository abstracts department persistence
+type DepartmentRepository interface {
+	// Create inserts a new department; it returns ErrDuplicate when the name is taken
+	Create(ctx context.Context, department *models.Department) error
+	// Get returns a current department by ID
+	Get(ctx context.Context, id uint) (*models.Department, error)
+	// Update replaces the name and description of a department; it returns
+	// ErrDuplicate when the name is taken
+	Update(ctx context.Context, id uint, replacement *models.Department) (*models.Department, error)
+	// Delete soft-deletes a department and returns how many employees left
+	// it. With a non-zero reassignTo its employees, deleted ones included,
+	// move to that department, which must be a current one or
+	// ErrInvalidReference is returned. Without it ErrInUse is returned while
+	// current employees belong to the department; deleted ones lose it.
+	Delete(ctx context.Context, id uint, reassignTo uint) (int64, error)
+	// List returns a page of current departments ordered by ID
+	List(ctx context.Context, page Page) (*DepartmentList, error)
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// GormDepartmentRepository is the PostgreSQL-backed DepartmentRepository
+type GormDepartmentRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDepartmentRepository creates a repository on top of a GORM connection
+func NewGormDepartmentRepository(db *gorm.DB) *GormDepartmentRepository {
+	return &GormDepartmentRepository{db: db}
+}
+
+// departmentsExist returns ErrInvalidReference unless every ID names a
+// current department. The foreign key only rejects departments that were
+// never created, not soft-deleted ones. Run inside a transaction, it share
+// locks the departments so none is deleted before the transaction ends.
+func departmentsExist(db *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	// FOR SHARE cannot lock an aggregate, so fetch the IDs instead of counting
+	var found []uint
+	err := db.Model(&models.Department{}).
+		Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("id IN ?", ids).
+		Pluck("id", &found).Error
+	if err != nil {
+		return err
+	}
+	if len(found) != len(ids) {
+		return ErrInvalidReference
+	}
+	return nil
+}
+
+// Create inserts a new department
+func (r *GormDepartmentRepository) Create(ctx context.Context, department *models.Department) error {
+	return translateWriteError(r.db.WithContext(ctx).Create(department).Error)
+}
+
+// Get returns a department by ID
+func (r *GormDepartmentRepository) Get(ctx context.Context, id uint) (*models.Department, error) {
+	var department models.Department
+	if err := r.db.WithContext(ctx).First(&department, id).Error; err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+// Update replaces the name and description of a department
+func (r *GormDepartmentRepository) Update(ctx context.Context, id uint, replacement *models.Department) (*models.Department, error) {
+	db := r.db.WithContext(ctx)
+
+	var department models.Department
+	if err := db.First(&department, id).Error; err != nil {
+		return nil, err
+	}
+	// Selecting the columns makes Updates write an empty description too
+	if err := db.Model(&department).Select("name", "description").Updates(replacement).Error; err != nil {
+		return nil, translateWriteError(err)
+	}
+	return r.Get(ctx, id)
+}
+
+// Delete soft-deletes a department after moving its employees
+func (r *GormDepartmentRepository) Delete(ctx context.Context, id uint, reassignTo uint) (int64, error) {
+	var moved int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the department waits for employee writes that share locked
+		// it in departmentsExist, and later ones wait for it and then find it
+		// deleted, so no employee joins it unnoticed. The target is locked too,
+		// and both in ID order so that opposite reassignments cannot deadlock.
+		ids := []uint{id}
+		if reassignTo != 0 {
+			if reassignTo == id {
+				return ErrInvalidReference
+			}
+			ids = append(ids, reassignTo)
+		}
+		var locked []models.Department
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", ids).
+			Order("id").
+			Find(&locked).Error
+		if err != nil {
+			return err
+		}
+
+		var department *models.Department
+		for i := range locked {
+			if locked[i].ID == id {
+				department = &locked[i]
+			}
+		}
+		if department == nil {
+			return gorm.ErrRecordNotFound
+		}
+
+		var target interface{}
+		if reassignTo != 0 {
+			// A deleted target is not found, like a missing one
+			if len(locked) != len(ids) {
+				return ErrInvalidReference
+			}
+			target = reassignTo
+		} else {
+			var members int64
+			if err := tx.Model(&models.Employee{}).Where("department_id = ?", id).Count(&members).Error; err != nil {
+				return err
+			}
+			if members > 0 {
+				return ErrInUse
+			}
+		}
+
+		// Deleted employees move as well, so that restoring one never brings
+		// back a deleted department
+		result := tx.Unscoped().Model(&models.Employee{}).
+			Where("department_id = ?", id).
+			Updates(map[string]interface{}{"department_id": target, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+
+		return tx.Delete(department).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// List returns a page of departments ordered by ID
+func (r *GormDepartmentRepository) List(ctx context.Context, page Page) (*DepartmentList, error) {
+	db := r.db.WithContext(ctx)
+
+	var total int64
+	if err := db.Model(&models.Department{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row to find out whether another page exists
+	var departments []models.Department
+	if err := db.Order("id").Offset(page.Offset).Limit(page.Limit + 1).Find(&departments).Error; err != nil {
+		return nil, err
+	}
+
+	list := &DepartmentList{Total: total}
+	if len(departments) > page.Limit {
+		departments = departments[:page.Limit]
+		list.HasMore = true
+	}
+	list.Departments = departments
+	return list, nil
+}
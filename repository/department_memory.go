@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// MemoryDepartmentRepository is an in-memory DepartmentRepository for tests and local development
+type MemoryDepartmentRepository struct {
+	mu          sync.RWMutex
+	employees   *MemoryEmployeeRepository
+	departments map[uint]models.Department
+	nextID      uint
+	now         func() time.Time
+}
+
+// NewMemoryDepartmentRepository creates an empty repository whose members
+// live in employees. From then on employees may only belong to departments
+// of this repository.
+func NewMemoryDepartmentRepository(employees *MemoryEmployeeRepository) *MemoryDepartmentRepository {
+	r := &MemoryDepartmentRepository{
+		employees:   employees,
+		departments: make(map[uint]models.Department),
+		nextID:      1,
+		now:         time.Now,
+	}
+
+	employees.mu.Lock()
+	employees.departments = r
+	employees.mu.Unlock()
+	return r
+}
+
+// exists reports whether a current department has the ID. Locks are taken
+// employees first, so callers may hold the employee repository's lock.
+func (r *MemoryDepartmentRepository) exists(id uint) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	department, ok := r.departments[id]
+	return ok && !department.DeletedAt.Valid
+}
+
+// nameTaken reports whether a current department other than exceptID has the
+// name; callers hold the lock
+func (r *MemoryDepartmentRepository) nameTaken(name string, exceptID uint) bool {
+	for id, department := range r.departments {
+		if id != exceptID && !department.DeletedAt.Valid && department.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Create inserts a new department
+func (r *MemoryDepartmentRepository) Create(_ context.Context, department *models.Department) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(department.Name, 0) {
+		return ErrDuplicate
+	}
+
+	now := r.now()
+	department.ID = r.nextID
+	department.CreatedAt = now
+	department.UpdatedAt = now
+	r.nextID++
+
+	r.departments[department.ID] = *department
+	return nil
+}
+
+// Get returns a department by ID
+func (r *MemoryDepartmentRepository) Get(_ context.Context, id uint) (*models.Department, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	department, ok := r.departments[id]
+	if !ok || department.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	return &department, nil
+}
+
+// Update replaces the name and description of a department
+func (r *MemoryDepartmentRepository) Update(_ context.Context, id uint, replacement *models.Department) (*models.Department, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	department, ok := r.departments[id]
+	if !ok || department.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if r.nameTaken(replacement.Name, id) {
+		return nil, ErrDuplicate
+	}
+
+	department.Name = replacement.Name
+	department.Description = replacement.Description
+	department.UpdatedAt = r.now()
+
+	r.departments[id] = department
+	return &department, nil
+}
+
+// Delete soft-deletes a department after moving its employees
+func (r *MemoryDepartmentRepository) Delete(_ context.Context, id uint, reassignTo uint) (int64, error) {
+	r.employees.mu.Lock()
+	defer r.employees.mu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	department, ok := r.departments[id]
+	if !ok || department.DeletedAt.Valid {
+		return 0, ErrNotFound
+	}
+
+	var target *uint
+	if reassignTo != 0 {
+		if other, ok := r.departments[reassignTo]; !ok || other.DeletedAt.Valid || reassignTo == id {
+			return 0, ErrInvalidReference
+		}
+		target = &reassignTo
+	} else {
+		for _, employee := range r.employees.employees {
+			if !employee.DeletedAt.Valid && employee.DepartmentID != nil && *employee.DepartmentID == id {
+				return 0, ErrInUse
+			}
+		}
+	}
+
+	// Deleted employees move as well, like in the database
+	var moved int64
+	now := r.now()
+	for employeeID, employee := range r.employees.employees {
+		if employee.DepartmentID == nil || *employee.DepartmentID != id {
+			continue
+		}
+		employee.DepartmentID = target
+		employee.Version++
+		employee.UpdatedAt = now
+		r.employees.employees[employeeID] = employee
+		moved++
+	}
+
+	department.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+	r.departments[id] = department
+	return moved, nil
+}
+
+// List returns a page of departments ordered by ID
+func (r *MemoryDepartmentRepository) List(_ context.Context, page Page) (*DepartmentList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	departments := make([]models.Department, 0, len(r.departments))
+	for _, department := range r.departments {
+		if !department.DeletedAt.Valid {
+			departments = append(departments, department)
+		}
+	}
+	sort.Slice(departments, func(i, j int) bool { return departments[i].ID < departments[j].ID })
+
+	list := &DepartmentList{Total: int64(len(departments))}
+	list.Departments, list.HasMore = pageOf(departments, page)
+	return list, nil
+}
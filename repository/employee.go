@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// EmployeeFilter narrows down which employees a list request returns
+type EmployeeFilter struct {
+	// FirstName and LastName match case-insensitive substrings
+	FirstName string
+	LastName  string
+	// CreatedAfter and CreatedBefore bound created_at inclusively
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// DepartmentID limits the list to one department's employees when non-zero
+	DepartmentID uint
+	// IncludeDeleted also returns soft-deleted employees
+	IncludeDeleted bool
+}
+
+// EmployeeListOptions controls filtering, ordering and paging of List
+type EmployeeListOptions struct {
+	Filter EmployeeFilter
+	// Sort must end with a unique column so that ordering is total
+	Sort   []SortField
+	Limit  int
+	Offset int
+	// Keyset switches to keyset pagination; Offset is ignored when set
+	Keyset *Keyset
+}
+
+// EmployeeList is one page of employees
+type EmployeeList struct {
+	Employees []models.Employee
+	// Total counts every employee matching the filter, ignoring paging
+	Total int64
+	// HasMore reports whether more rows exist past the page in the paging direction
+	HasMore bool
+}
+
+// EmployeeSearchResult is an employee found by Search
+type EmployeeSearchResult struct {
+	Employee models.Employee
+	// Score ranks results of one search; higher is a better match
+	Score float64
+}
+
+// EmployeeWritableColumns are the employee columns clients may set; the
+// others are managed by the database
+var EmployeeWritableColumns = []string{"employee_number", "first_name", "last_name", "department_id", "salary"}
+
+// EmployeeInsertBatchSize is the most employees CreateBatch inserts per statement
+const EmployeeInsertBatchSize = 100
+
+// EmployeeRepository abstracts employee persistence
+type EmployeeRepository interface {
+	// Create inserts a new employee and fills in its ID and timestamps. Writes
+	// return ErrDuplicate when the employee number belongs to another employee
+	// and ErrInvalidReference when the department is not a current one.
+	Create(ctx context.Context, employee *models.Employee) error
+	// CreateBatch inserts employees in one transaction, EmployeeInsertBatchSize
+	// rows per statement, and fills in their IDs and timestamps
+	CreateBatch(ctx context.Context, employees []*models.Employee) error
+	// Get returns an employee by ID, optionally including soft-deleted rows
+	Get(ctx context.Context, id uint, includeDeleted bool) (*models.Employee, error)
+	// FindByEmployeeNumbers returns the current employees with any of the
+	// given employee numbers, in no particular order
+	FindByEmployeeNumbers(ctx context.Context, numbers []string) ([]models.Employee, error)
+	// Update replaces the writable fields of an existing employee with those
+	// of replacement; zero values clear fields. The version is incremented.
+	// A non-zero version must match the stored one, or ErrVersionConflict is returned.
+	Update(ctx context.Context, id uint, replacement *models.Employee, version uint) (*models.Employee, error)
+	// Delete soft-deletes an employee. A non-zero version must match the
+	// stored one, or ErrVersionConflict is returned.
+	Delete(ctx context.Context, id uint, version uint) error
+	// Restore clears the soft delete of an employee
+	Restore(ctx context.Context, id uint) (*models.Employee, error)
+	// Purge permanently removes an employee, deleted or not
+	Purge(ctx context.Context, id uint) error
+	// List returns a filtered, sorted page of employees
+	List(ctx context.Context, opts EmployeeListOptions) (*EmployeeList, error)
+	// Search returns a page of the current employees whose names have words
+	// starting with every word of the query, or are similar to the query,
+	// best matches first
+	Search(ctx context.Context, query string, page Page) ([]EmployeeSearchResult, error)
+	// Stream calls fn with every employee matching the filter, in sort order,
+	// reading rows as they are needed instead of loading them all. It stops
+	// at the first error fn returns and returns it.
+	Stream(ctx context.Context, filter EmployeeFilter, sort []SortField, fn func(employee *models.Employee) error) error
+	// Transaction runs fn with a repository whose writes are committed
+	// together when fn returns nil and rolled back when it returns an error
+	Transaction(ctx context.Context, fn func(repo EmployeeRepository) error) error
+}
+
+// EmployeeColumnValue returns the value of an employee column used for sorting and keysets
+func EmployeeColumnValue(e *models.Employee, column string) interface{} {
+	switch column {
+	case "id":
+		return e.ID
+	case "first_name":
+		return e.FirstName
+	case "last_name":
+		return e.LastName
+	case "created_at":
+		return e.CreatedAt
+	case "updated_at":
+		return e.UpdatedAt
+	default:
+		return nil
+	}
+}
+
+// employeeDepartmentIDs returns the distinct departments the employees belong to
+func employeeDepartmentIDs(employees ...*models.Employee) []uint {
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, employee := range employees {
+		if id := employee.DepartmentID; id != nil && !seen[*id] {
+			seen[*id] = true
+			ids = append(ids, *id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/search"
+)
+
+// GormEmployeeRepository is the PostgreSQL-backed EmployeeRepository
+type GormEmployeeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEmployeeRepository creates a repository on top of a GORM connection
+func NewGormEmployeeRepository(db *gorm.DB) *GormEmployeeRepository {
+	return &GormEmployeeRepository{db: db}
+}
+
+// Create inserts a new employee
+func (r *GormEmployeeRepository) Create(ctx context.Context, employee *models.Employee) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := departmentsExist(tx, employeeDepartmentIDs(employee)); err != nil {
+			return err
+		}
+		return translateWriteError(tx.Create(employee).Error)
+	})
+}
+
+// CreateBatch inserts employees with multi-row inserts
+func (r *GormEmployeeRepository) CreateBatch(ctx context.Context, employees []*models.Employee) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := departmentsExist(tx, employeeDepartmentIDs(employees...)); err != nil {
+			return err
+		}
+		return translateWriteError(tx.CreateInBatches(employees, EmployeeInsertBatchSize).Error)
+	})
+}
+
+// Get returns an employee by ID
+func (r *GormEmployeeRepository) Get(ctx context.Context, id uint, includeDeleted bool) (*models.Employee, error) {
+	db := r.db.WithContext(ctx)
+	if includeDeleted {
+		db = db.Unscoped()
+	}
+
+	var employee models.Employee
+	if err := db.First(&employee, id).Error; err != nil {
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// FindByEmployeeNumbers returns the current employees with the given employee numbers
+func (r *GormEmployeeRepository) FindByEmployeeNumbers(ctx context.Context, numbers []string) ([]models.Employee, error) {
+	var employees []models.Employee
+	if len(numbers) == 0 {
+		return employees, nil
+	}
+	if err := r.db.WithContext(ctx).Where("employee_number IN ?", numbers).Find(&employees).Error; err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+// Update replaces the writable fields of an existing employee
+func (r *GormEmployeeRepository) Update(ctx context.Context, id uint, replacement *models.Employee, version uint) (*models.Employee, error) {
+	// The department check holds its lock until the write commits
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var employee models.Employee
+		if err := tx.First(&employee, id).Error; err != nil {
+			return err
+		}
+		if version != 0 && employee.Version != version {
+			return ErrVersionConflict
+		}
+		if err := departmentsExist(tx, employeeDepartmentIDs(replacement)); err != nil {
+			return err
+		}
+
+		// Selecting the columns makes Updates write zero values too. The version
+		// condition catches writes that happened since the employee was read.
+		values := *replacement
+		values.Version = employee.Version + 1
+		result := tx.Model(&employee).
+			Where("version = ?", employee.Version).
+			Select(append(EmployeeWritableColumns, "version")).
+			Updates(&values)
+		if result.Error != nil {
+			return translateWriteError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Get(ctx, id, false)
+}
+
+// Delete soft-deletes an employee
+func (r *GormEmployeeRepository) Delete(ctx context.Context, id uint, version uint) error {
+	query := r.db.WithContext(ctx)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Delete(&models.Employee{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if version == 0 {
+			return ErrNotFound
+		}
+		// Tell a missing employee from one at another version
+		if _, err := r.Get(ctx, id, false); err != nil {
+			return err
+		}
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// Restore clears the soft delete of an employee
+func (r *GormEmployeeRepository) Restore(ctx context.Context, id uint) (*models.Employee, error) {
+	employee, err := r.Get(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if !employee.DeletedAt.Valid {
+		return nil, ErrNotDeleted
+	}
+
+	restore := map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")}
+	if err := r.db.WithContext(ctx).Unscoped().Model(employee).Updates(restore).Error; err != nil {
+		return nil, translateWriteError(err)
+	}
+
+	return r.Get(ctx, id, false)
+}
+
+// Purge permanently removes an employee
+func (r *GormEmployeeRepository) Purge(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Delete(&models.Employee{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns a filtered, sorted page of employees
+func (r *GormEmployeeRepository) List(ctx context.Context, opts EmployeeListOptions) (*EmployeeList, error) {
+	db := r.db.WithContext(ctx)
+	if opts.Filter.IncludeDeleted {
+		db = db.Unscoped()
+	}
+
+	// Count all matching rows, ignoring paging
+	var total int64
+	if err := db.Model(&models.Employee{}).Scopes(employeeFilterScope(opts.Filter)).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&models.Employee{}).Scopes(employeeFilterScope(opts.Filter))
+
+	backwards := false
+	if opts.Keyset != nil {
+		backwards = opts.Keyset.Backwards
+		query = query.Scopes(keysetScope(opts.Sort, opts.Keyset))
+	} else {
+		query = query.Offset(opts.Offset)
+	}
+
+	// Fetch one extra row to find out whether another page exists
+	var employees []models.Employee
+	err := query.Scopes(orderScope(opts.Sort, backwards)).
+		Limit(opts.Limit + 1).
+		Find(&employees).Error
+	if err != nil {
+		return nil, err
+	}
+
+	list := &EmployeeList{Total: total}
+	if len(employees) > opts.Limit {
+		employees = employees[:opts.Limit]
+		list.HasMore = true
+	}
+
+	// Rows fetched backwards come out in reverse order
+	if backwards {
+		reverseEmployees(employees)
+	}
+	list.Employees = employees
+
+	return list, nil
+}
+
+// Search combines a prefix match on the search_vector column with trigram
+// word similarity on the names, both served by GIN indexes
+func (r *GormEmployeeRepository) Search(ctx context.Context, query string, page Page) ([]EmployeeSearchResult, error) {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return []EmployeeSearchResult{}, nil
+	}
+	prefixes := search.PrefixQuery(terms)
+	words := strings.Join(terms, " ")
+
+	var rows []struct {
+		models.Employee
+		Score float64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Employee{}).
+		Select("employees.*, ts_rank(search_vector, to_tsquery('simple', ?)) + "+
+			"GREATEST(word_similarity(first_name, ?), word_similarity(last_name, ?)) AS score", prefixes, words, words).
+		Where("search_vector @@ to_tsquery('simple', ?) OR first_name <% ? OR last_name <% ?", prefixes, words, words).
+		Order("score DESC").
+		Order("id").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]EmployeeSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = EmployeeSearchResult{Employee: row.Employee, Score: row.Score}
+	}
+	return results, nil
+}
+
+// Stream reads matching employees from a database cursor one row at a time
+func (r *GormEmployeeRepository) Stream(ctx context.Context, filter EmployeeFilter, sort []SortField, fn func(employee *models.Employee) error) error {
+	db := r.db.WithContext(ctx)
+	if filter.IncludeDeleted {
+		db = db.Unscoped()
+	}
+
+	rows, err := db.Model(&models.Employee{}).
+		Scopes(employeeFilterScope(filter), orderScope(sort, false)).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var employee models.Employee
+		if err := db.ScanRows(rows, &employee); err != nil {
+			return err
+		}
+		if err := fn(&employee); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Transaction runs fn in a database transaction
+func (r *GormEmployeeRepository) Transaction(ctx context.Context, fn func(repo EmployeeRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormEmployeeRepository{db: tx})
+	})
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// employeeFilterScope applies the list filters to a query
+func employeeFilterScope(filter EmployeeFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.FirstName != "" {
+			db = db.Where("first_name ILIKE ?", "%"+escapeLike(filter.FirstName)+"%")
+		}
+		if filter.LastName != "" {
+			db = db.Where("last_name ILIKE ?", "%"+escapeLike(filter.LastName)+"%")
+		}
+		if filter.CreatedAfter != nil {
+			db = db.Where("created_at >= ?", *filter.CreatedAfter)
+		}
+		if filter.CreatedBefore != nil {
+			db = db.Where("created_at <= ?", *filter.CreatedBefore)
+		}
+		if filter.DepartmentID != 0 {
+			db = db.Where("department_id = ?", filter.DepartmentID)
+		}
+		return db
+	}
+}
+
+// orderScope applies the sort fields, reversed when paging backwards
+func orderScope(sort []SortField, reverse bool) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, field := range sort {
+			db = db.Order(clause.OrderByColumn{
+				Column: clause.Column{Name: field.Column},
+				Desc:   field.Desc != reverse,
+			})
+		}
+		return db
+	}
+}
+
+// keysetScope restricts a query to the rows after (or before) the keyset.
+// For sort (a, b) it produces: a > ? OR (a = ? AND b > ?).
+func keysetScope(sort []SortField, keyset *Keyset) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		disjuncts := make([]clause.Expression, 0, len(sort))
+
+		for i, field := range sort {
+			conds := make([]clause.Expression, 0, i+1)
+			for j := 0; j < i; j++ {
+				conds = append(conds, clause.Eq{
+					Column: clause.Column{Name: sort[j].Column},
+					Value:  keyset.Values[j],
+				})
+			}
+
+			// Rows come after the boundary in ascending order unless exactly one
+			// of the field direction and the paging direction is reversed
+			column := clause.Column{Name: field.Column}
+			if field.Desc != keyset.Backwards {
+				conds = append(conds, clause.Lt{Column: column, Value: keyset.Values[i]})
+			} else {
+				conds = append(conds, clause.Gt{Column: column, Value: keyset.Values[i]})
+			}
+
+			disjuncts = append(disjuncts, clause.And(conds...))
+		}
+
+		return db.Where(clause.Or(disjuncts...))
+	}
+}
+
+// reverseEmployees reverses a slice of employees in place
+func reverseEmployees(employees []models.Employee) {
+	for i, j := 0, len(employees)-1; i < j; i, j = i+1, j-1 {
+		employees[i], employees[j] = employees[j], employees[i]
+	}
+}
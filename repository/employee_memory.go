@@ -0,0 +1,444 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+	"github.com/yourname/employee-api/search"
+)
+
+// MemoryEmployeeRepository is an in-memory EmployeeRepository for tests and local development
+type MemoryEmployeeRepository struct {
+	mu sync.RWMutex
+	// txMu serializes transactions
+	txMu      sync.Mutex
+	employees map[uint]models.Employee
+	nextID    uint
+	now       func() time.Time
+	// departments holds the departments employees may belong to, once a
+	// MemoryDepartmentRepository was created on top of this repository
+	departments *MemoryDepartmentRepository
+}
+
+// NewMemoryEmployeeRepository creates an empty in-memory repository
+func NewMemoryEmployeeRepository() *MemoryEmployeeRepository {
+	return &MemoryEmployeeRepository{
+		employees: make(map[uint]models.Employee),
+		nextID:    1,
+		now:       time.Now,
+	}
+}
+
+// Create inserts a new employee
+func (r *MemoryEmployeeRepository) Create(_ context.Context, employee *models.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.numberTaken(employee.EmployeeNumber, 0) {
+		return ErrDuplicate
+	}
+	if err := r.departmentsExist(employee); err != nil {
+		return err
+	}
+	r.insert(employee)
+	return nil
+}
+
+// CreateBatch inserts employees
+func (r *MemoryEmployeeRepository) CreateBatch(_ context.Context, employees []*models.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Check every employee first so that a duplicate inserts none of them
+	numbers := make(map[string]bool, len(employees))
+	for _, employee := range employees {
+		number := employee.EmployeeNumber
+		if r.numberTaken(number, 0) || (number != "" && numbers[number]) {
+			return ErrDuplicate
+		}
+		numbers[number] = true
+	}
+	if err := r.departmentsExist(employees...); err != nil {
+		return err
+	}
+	for _, employee := range employees {
+		r.insert(employee)
+	}
+	return nil
+}
+
+// numberTaken reports whether a current employee other than exceptID has the
+// employee number; callers hold the lock
+func (r *MemoryEmployeeRepository) numberTaken(number string, exceptID uint) bool {
+	if number == "" {
+		return false
+	}
+	for id, employee := range r.employees {
+		if id != exceptID && !employee.DeletedAt.Valid && employee.EmployeeNumber == number {
+			return true
+		}
+	}
+	return false
+}
+
+// departmentsExist returns ErrInvalidReference unless the departments of the
+// employees are current ones; callers hold the lock
+func (r *MemoryEmployeeRepository) departmentsExist(employees ...*models.Employee) error {
+	for _, id := range employeeDepartmentIDs(employees...) {
+		if r.departments == nil || !r.departments.exists(id) {
+			return ErrInvalidReference
+		}
+	}
+	return nil
+}
+
+// insert assigns an ID, timestamps and the first version and stores the
+// employee; callers hold the lock
+func (r *MemoryEmployeeRepository) insert(employee *models.Employee) {
+	now := r.now()
+	employee.ID = r.nextID
+	employee.CreatedAt = now
+	employee.UpdatedAt = now
+	employee.Version = 1
+	r.nextID++
+
+	r.employees[employee.ID] = *employee
+}
+
+// Get returns an employee by ID
+func (r *MemoryEmployeeRepository) Get(_ context.Context, id uint, includeDeleted bool) (*models.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	employee, ok := r.employees[id]
+	if !ok || (employee.DeletedAt.Valid && !includeDeleted) {
+		return nil, ErrNotFound
+	}
+	return &employee, nil
+}
+
+// FindByEmployeeNumbers returns the current employees with the given employee numbers
+func (r *MemoryEmployeeRepository) FindByEmployeeNumbers(_ context.Context, numbers []string) ([]models.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(numbers))
+	for _, number := range numbers {
+		wanted[number] = true
+	}
+	employees := []models.Employee{}
+	for _, employee := range r.employees {
+		if !employee.DeletedAt.Valid && employee.EmployeeNumber != "" && wanted[employee.EmployeeNumber] {
+			employees = append(employees, employee)
+		}
+	}
+	return employees, nil
+}
+
+// Update replaces the writable fields of an existing employee
+func (r *MemoryEmployeeRepository) Update(_ context.Context, id uint, replacement *models.Employee, version uint) (*models.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	employee, ok := r.employees[id]
+	if !ok || employee.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if version != 0 && employee.Version != version {
+		return nil, ErrVersionConflict
+	}
+	if r.numberTaken(replacement.EmployeeNumber, id) {
+		return nil, ErrDuplicate
+	}
+	if err := r.departmentsExist(replacement); err != nil {
+		return nil, err
+	}
+
+	employee.EmployeeNumber = replacement.EmployeeNumber
+	employee.FirstName = replacement.FirstName
+	employee.LastName = replacement.LastName
+	employee.DepartmentID = replacement.DepartmentID
+	employee.Salary = replacement.Salary
+	employee.Version++
+	employee.UpdatedAt = r.now()
+
+	r.employees[id] = employee
+	return &employee, nil
+}
+
+// Delete soft-deletes an employee
+func (r *MemoryEmployeeRepository) Delete(_ context.Context, id uint, version uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	employee, ok := r.employees[id]
+	if !ok || employee.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	if version != 0 && employee.Version != version {
+		return ErrVersionConflict
+	}
+
+	employee.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+	r.employees[id] = employee
+	return nil
+}
+
+// Restore clears the soft delete of an employee
+func (r *MemoryEmployeeRepository) Restore(_ context.Context, id uint) (*models.Employee, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	employee, ok := r.employees[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !employee.DeletedAt.Valid {
+		return nil, ErrNotDeleted
+	}
+	if r.numberTaken(employee.EmployeeNumber, id) {
+		return nil, ErrDuplicate
+	}
+
+	employee.DeletedAt = gorm.DeletedAt{}
+	employee.Version++
+	employee.UpdatedAt = r.now()
+	r.employees[id] = employee
+	return &employee, nil
+}
+
+// Purge permanently removes an employee
+func (r *MemoryEmployeeRepository) Purge(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.employees[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.employees, id)
+	return nil
+}
+
+// List returns a filtered, sorted page of employees
+func (r *MemoryEmployeeRepository) List(_ context.Context, opts EmployeeListOptions) (*EmployeeList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]models.Employee, 0, len(r.employees))
+	for _, employee := range r.employees {
+		if matchesEmployeeFilter(&employee, opts.Filter) {
+			matched = append(matched, employee)
+		}
+	}
+
+	list := &EmployeeList{Total: int64(len(matched))}
+
+	backwards := opts.Keyset != nil && opts.Keyset.Backwards
+	sort.SliceStable(matched, func(i, j int) bool {
+		return compareEmployees(&matched[i], &matched[j], opts.Sort, backwards) < 0
+	})
+
+	// Skip to the first row of the page
+	start := 0
+	if opts.Keyset != nil {
+		start = len(matched)
+		for i := range matched {
+			if afterKeyset(&matched[i], opts.Sort, opts.Keyset) {
+				start = i
+				break
+			}
+		}
+	} else if opts.Offset < len(matched) {
+		start = opts.Offset
+	} else {
+		start = len(matched)
+	}
+
+	page := matched[start:]
+	if len(page) > opts.Limit {
+		page = page[:opts.Limit]
+		list.HasMore = true
+	}
+
+	list.Employees = make([]models.Employee, len(page))
+	copy(list.Employees, page)
+
+	// Rows fetched backwards come out in reverse order
+	if backwards {
+		reverseEmployees(list.Employees)
+	}
+
+	return list, nil
+}
+
+// prefixMatchRank stands in for the ts_rank of a prefix match in Search
+const prefixMatchRank = 0.1
+
+// Search matches names by prefix and trigram word similarity like the
+// database does; scores are close to, not equal to, the database's
+func (r *MemoryEmployeeRepository) Search(_ context.Context, query string, page Page) ([]EmployeeSearchResult, error) {
+	terms := search.Terms(query)
+	words := strings.Join(terms, " ")
+
+	r.mu.RLock()
+	results := []EmployeeSearchResult{}
+	for _, employee := range r.employees {
+		if employee.DeletedAt.Valid || len(terms) == 0 {
+			continue
+		}
+		similarity := max(search.WordSimilarity(employee.FirstName, words), search.WordSimilarity(employee.LastName, words))
+		prefixed := search.HasPrefixes(employee.FirstName+" "+employee.LastName, terms)
+		if !prefixed && similarity < search.WordSimilarityThreshold {
+			continue
+		}
+		score := similarity
+		if prefixed {
+			score += prefixMatchRank
+		}
+		results = append(results, EmployeeSearchResult{Employee: employee, Score: score})
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Employee.ID < results[j].Employee.ID
+	})
+	if page.Offset >= len(results) {
+		return []EmployeeSearchResult{}, nil
+	}
+	results = results[page.Offset:]
+	if len(results) > page.Limit {
+		results = results[:page.Limit]
+	}
+	return results, nil
+}
+
+// Stream calls fn with a snapshot of the matching employees, taken before
+// the first call so that fn may use the repository
+func (r *MemoryEmployeeRepository) Stream(_ context.Context, filter EmployeeFilter, fields []SortField, fn func(employee *models.Employee) error) error {
+	r.mu.RLock()
+	matched := make([]models.Employee, 0, len(r.employees))
+	for _, employee := range r.employees {
+		if matchesEmployeeFilter(&employee, filter) {
+			matched = append(matched, employee)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return compareEmployees(&matched[i], &matched[j], fields, false) < 0
+	})
+	for i := range matched {
+		if err := fn(&matched[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transaction runs fn and restores the previous contents if it fails.
+// Transactions run one at a time but see writes made outside them.
+func (r *MemoryEmployeeRepository) Transaction(_ context.Context, fn func(repo EmployeeRepository) error) error {
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+
+	r.mu.RLock()
+	employees, nextID := maps.Clone(r.employees), r.nextID
+	r.mu.RUnlock()
+
+	if err := fn(r); err != nil {
+		r.mu.Lock()
+		r.employees, r.nextID = employees, nextID
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// matchesEmployeeFilter reports whether an employee passes the list filters
+func matchesEmployeeFilter(e *models.Employee, filter EmployeeFilter) bool {
+	if e.DeletedAt.Valid && !filter.IncludeDeleted {
+		return false
+	}
+	if filter.FirstName != "" && !containsFold(e.FirstName, filter.FirstName) {
+		return false
+	}
+	if filter.LastName != "" && !containsFold(e.LastName, filter.LastName) {
+		return false
+	}
+	if filter.CreatedAfter != nil && e.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && e.CreatedAt.After(*filter.CreatedBefore) {
+		return false
+	}
+	if filter.DepartmentID != 0 && (e.DepartmentID == nil || *e.DepartmentID != filter.DepartmentID) {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether substr is within s, ignoring case
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// compareEmployees orders two employees by the sort fields
+func compareEmployees(a, b *models.Employee, fields []SortField, reverse bool) int {
+	for _, field := range fields {
+		cmp := compareValues(EmployeeColumnValue(a, field.Column), EmployeeColumnValue(b, field.Column))
+		if cmp == 0 {
+			continue
+		}
+		if field.Desc != reverse {
+			return -cmp
+		}
+		return cmp
+	}
+	return 0
+}
+
+// afterKeyset reports whether an employee sorts strictly after the keyset boundary
+func afterKeyset(e *models.Employee, fields []SortField, keyset *Keyset) bool {
+	for i, field := range fields {
+		cmp := compareValues(EmployeeColumnValue(e, field.Column), keyset.Values[i])
+		if cmp == 0 {
+			continue
+		}
+		if field.Desc != keyset.Backwards {
+			return cmp < 0
+		}
+		return cmp > 0
+	}
+	return false
+}
+
+// compareValues compares two column values of the same type
+func compareValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case uint:
+		bv, _ := b.(uint)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	case string:
+		bv, _ := b.(string)
+		return strings.Compare(av, bv)
+	case time.Time:
+		bv, _ := b.(time.Time)
+		return av.Compare(bv)
+	}
+	return 0
+}
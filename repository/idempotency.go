@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// IdempotencyRepository abstracts persistence of Idempotency-Key records
+type IdempotencyRepository interface {
+	// Reserve stores an in-flight record unless an unexpired record with the
+	// same subject and key exists. It returns the stored record and whether it
+	// is the one just reserved; expired records are replaced.
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error)
+	// Complete stores the response of a reserved record and its new expiry
+	Complete(ctx context.Context, record *models.IdempotencyKey) error
+	// Release removes a reserved record that has not completed so its key can be retried
+	Release(ctx context.Context, subject, key string) error
+	// DeleteExpired removes records that expired before the given time
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
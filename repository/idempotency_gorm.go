@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// reserveAttempts bounds how often Reserve retries when the record it
+// conflicted with disappears before it could be read
+const reserveAttempts = 3
+
+// GormIdempotencyRepository is the PostgreSQL-backed IdempotencyRepository
+type GormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewGormIdempotencyRepository creates a repository on top of a GORM connection
+func NewGormIdempotencyRepository(db *gorm.DB) *GormIdempotencyRepository {
+	return &GormIdempotencyRepository{db: db}
+}
+
+// Reserve stores an in-flight record unless an unexpired one exists. The
+// insert and the check are one statement, so of two concurrent requests with
+// the same key exactly one reserves it.
+func (r *GormIdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	db := r.db.WithContext(ctx)
+	for attempt := 1; ; attempt++ {
+		// An expired record no longer guards its key
+		if err := db.Where("subject = ? AND key = ? AND expires_at <= ?", record.Subject, record.Key, record.CreatedAt).
+			Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return nil, false, err
+		}
+
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			return nil, false, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return record, true, nil
+		}
+
+		var existing models.IdempotencyKey
+		err := db.Where("subject = ? AND key = ?", record.Subject, record.Key).First(&existing).Error
+		if err == nil {
+			return &existing, false, nil
+		}
+		// The other request released its key in between; try to take it
+		if !errors.Is(err, ErrNotFound) || attempt == reserveAttempts {
+			return nil, false, err
+		}
+	}
+}
+
+// Complete stores the response of a reserved record
+func (r *GormIdempotencyRepository) Complete(ctx context.Context, record *models.IdempotencyKey) error {
+	return r.db.WithContext(ctx).Model(record).
+		Where("status_code = 0").
+		Select("status_code", "header", "body", "expires_at").
+		Updates(record).Error
+}
+
+// Release removes a reserved record that has not completed
+func (r *GormIdempotencyRepository) Release(ctx context.Context, subject, key string) error {
+	return r.db.WithContext(ctx).
+		Where("subject = ? AND key = ? AND status_code = 0", subject, key).
+		Delete(&models.IdempotencyKey{}).Error
+}
+
+// DeleteExpired removes records that expired before the given time
+func (r *GormIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", before).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// idempotencyID identifies a record by its caller and key
+type idempotencyID struct {
+	subject string
+	key     string
+}
+
+// MemoryIdempotencyRepository is an in-memory IdempotencyRepository for tests and local development
+type MemoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[idempotencyID]models.IdempotencyKey
+}
+
+// NewMemoryIdempotencyRepository creates an empty repository
+func NewMemoryIdempotencyRepository() *MemoryIdempotencyRepository {
+	return &MemoryIdempotencyRepository{records: make(map[idempotencyID]models.IdempotencyKey)}
+}
+
+// Reserve stores an in-flight record unless an unexpired one exists
+func (r *MemoryIdempotencyRepository) Reserve(_ context.Context, record *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyID{subject: record.Subject, key: record.Key}
+	if existing, ok := r.records[id]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return &existing, false, nil
+	}
+	r.records[id] = *record
+	return record, true, nil
+}
+
+// Complete stores the response of a reserved record
+func (r *MemoryIdempotencyRepository) Complete(_ context.Context, record *models.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyID{subject: record.Subject, key: record.Key}
+	existing, ok := r.records[id]
+	if !ok || existing.Completed() {
+		return nil
+	}
+	existing.StatusCode = record.StatusCode
+	existing.Header = record.Header
+	existing.Body = record.Body
+	existing.ExpiresAt = record.ExpiresAt
+	r.records[id] = existing
+	return nil
+}
+
+// Release removes a reserved record that has not completed
+func (r *MemoryIdempotencyRepository) Release(_ context.Context, subject, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyID{subject: subject, key: key}
+	if existing, ok := r.records[id]; ok && !existing.Completed() {
+		delete(r.records, id)
+	}
+	return nil
+}
+
+// DeleteExpired removes records that expired before the given time
+func (r *MemoryIdempotencyRepository) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, record := range r.records {
+		if !record.ExpiresAt.After(before) {
+			delete(r.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// GormPostRepository is the PostgreSQL-backed PostRepository
+type GormPostRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPostRepository creates a repository on top of a GORM connection
+func NewGormPostRepository(db *gorm.DB) *GormPostRepository {
+	return &GormPostRepository{db: db}
+}
+
+// Create inserts a new post
+func (r *GormPostRepository) Create(ctx context.Context, post *models.Post) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := userExists(tx, post.UserID); err != nil {
+			return err
+		}
+		return tx.Omit("User").Create(post).Error
+	})
+}
+
+// Get returns a post by ID
+func (r *GormPostRepository) Get(ctx context.Context, id uint, includeUser bool) (*models.Post, error) {
+	db := r.db.WithContext(ctx)
+	if includeUser {
+		db = db.Preload("User")
+	}
+
+	var post models.Post
+	if err := db.First(&post, id).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// Update applies the non-zero title and content of changes to an existing post
+func (r *GormPostRepository) Update(ctx context.Context, id uint, changes *models.Post) (*models.Post, error) {
+	db := r.db.WithContext(ctx)
+
+	var post models.Post
+	if err := db.First(&post, id).Error; err != nil {
+		return nil, err
+	}
+	// Posts cannot move between users, so only title and content are applied
+	if err := db.Model(&post).Updates(models.Post{Title: changes.Title, Content: changes.Content}).Error; err != nil {
+		return nil, err
+	}
+	return r.Get(ctx, id, false)
+}
+
+// Delete soft-deletes a post
+func (r *GormPostRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Post{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns a page of posts ordered by ID
+func (r *GormPostRepository) List(ctx context.Context, opts PostListOptions) (*PostList, error) {
+	db := r.db.WithContext(ctx)
+	if opts.UserID != 0 {
+		if err := userExists(db, opts.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		if opts.UserID != 0 {
+			db = db.Where("user_id = ?", opts.UserID)
+		}
+		return db
+	}
+
+	var total int64
+	if err := db.Model(&models.Post{}).Scopes(filter).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	query := db.Model(&models.Post{}).Scopes(filter)
+	if opts.IncludeUser {
+		query = query.Preload("User")
+	}
+
+	// Fetch one extra row to find out whether another page exists
+	var posts []models.Post
+	if err := query.Order("id").Offset(opts.Page.Offset).Limit(opts.Page.Limit + 1).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	list := &PostList{Total: total}
+	if len(posts) > opts.Page.Limit {
+		posts = posts[:opts.Page.Limit]
+		list.HasMore = true
+	}
+	list.Posts = posts
+	return list, nil
+}
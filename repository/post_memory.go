@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// MemoryPostRepository is an in-memory PostRepository for tests and local development
+type MemoryPostRepository struct {
+	mu     sync.RWMutex
+	users  *MemoryUserRepository
+	posts  map[uint]models.Post
+	nextID uint
+	now    func() time.Time
+}
+
+// NewMemoryPostRepository creates an empty repository whose authors live in users
+func NewMemoryPostRepository(users *MemoryUserRepository) *MemoryPostRepository {
+	return &MemoryPostRepository{
+		users:  users,
+		posts:  make(map[uint]models.Post),
+		nextID: 1,
+		now:    time.Now,
+	}
+}
+
+// Create inserts a new post
+func (r *MemoryPostRepository) Create(ctx context.Context, post *models.Post) error {
+	if _, err := r.users.Get(ctx, post.UserID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	post.ID = r.nextID
+	post.CreatedAt = now
+	post.UpdatedAt = now
+	post.User = nil
+	r.nextID++
+
+	r.posts[post.ID] = *post
+	return nil
+}
+
+// Get returns a post by ID
+func (r *MemoryPostRepository) Get(ctx context.Context, id uint, includeUser bool) (*models.Post, error) {
+	r.mu.RLock()
+	post, ok := r.posts[id]
+	r.mu.RUnlock()
+
+	if !ok || post.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if includeUser {
+		r.loadUser(ctx, &post)
+	}
+	return &post, nil
+}
+
+// loadUser mirrors GORM's Preload, which leaves deleted authors unset
+func (r *MemoryPostRepository) loadUser(ctx context.Context, post *models.Post) {
+	if user, err := r.users.Get(ctx, post.UserID); err == nil {
+		post.User = user
+	}
+}
+
+// Update applies the non-zero title and content of changes to an existing post
+func (r *MemoryPostRepository) Update(_ context.Context, id uint, changes *models.Post) (*models.Post, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[id]
+	if !ok || post.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if changes.Title != "" {
+		post.Title = changes.Title
+	}
+	if changes.Content != "" {
+		post.Content = changes.Content
+	}
+	post.UpdatedAt = r.now()
+
+	r.posts[id] = post
+	return &post, nil
+}
+
+// Delete soft-deletes a post
+func (r *MemoryPostRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	post, ok := r.posts[id]
+	if !ok || post.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	post.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+	r.posts[id] = post
+	return nil
+}
+
+// List returns a page of posts ordered by ID
+func (r *MemoryPostRepository) List(ctx context.Context, opts PostListOptions) (*PostList, error) {
+	if opts.UserID != 0 {
+		if _, err := r.users.Get(ctx, opts.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.RLock()
+	posts := make([]models.Post, 0, len(r.posts))
+	for _, post := range r.posts {
+		if !post.DeletedAt.Valid && (opts.UserID == 0 || post.UserID == opts.UserID) {
+			posts = append(posts, post)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(posts, func(i, j int) bool { return posts[i].ID < posts[j].ID })
+
+	list := &PostList{Total: int64(len(posts))}
+	list.Posts, list.HasMore = pageOf(posts, opts.Page)
+	if opts.IncludeUser {
+		for i := range list.Posts {
+			r.loadUser(ctx, &list.Posts[i])
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/utils"
+)
+
+var (
+	// ErrNotFound is returned when a record does not exist.
+	// It aliases gorm.ErrRecordNotFound so callers can check either.
+	ErrNotFound = gorm.ErrRecordNotFound
+
+	// ErrNotDeleted is returned when restoring a record that is not soft-deleted
+	ErrNotDeleted = errors.New("record is not deleted")
+
+	// ErrVersionConflict is returned when a conditional write finds the
+	// record at a different version than the caller expected
+	ErrVersionConflict = errors.New("record was modified concurrently")
+
+	// ErrDuplicate is returned when a write violates a unique constraint.
+	// It aliases gorm.ErrDuplicatedKey so callers can check either.
+	ErrDuplicate = gorm.ErrDuplicatedKey
+
+	// ErrInvalidReference is returned when a write refers to a record that
+	// does not exist. It aliases gorm.ErrForeignKeyViolated so callers can check either.
+	ErrInvalidReference = gorm.ErrForeignKeyViolated
+
+	// ErrInUse is returned when deleting a record that others still refer to
+	ErrInUse = errors.New("record is still referenced")
+)
+
+// Page selects a window of an offset-paginated list
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// translateWriteError marks unique constraint violations as ErrDuplicate and
+// foreign key violations as ErrInvalidReference, keeping the driver error for
+// callers that inspect it
+func translateWriteError(err error) error {
+	translated := utils.TranslateDBError(err)
+	if translated.SQLState == "" {
+		return err
+	}
+	switch translated.Code {
+	case utils.ErrCodeDuplicate:
+		return fmt.Errorf("%w: %w", ErrDuplicate, err)
+	case utils.ErrCodeForeignKeyViolation:
+		return fmt.Errorf("%w: %w", ErrInvalidReference, err)
+	}
+	return err
+}
+
+// SortField is a single ORDER BY term
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// Keyset positions a list request relative to a boundary row
+type Keyset struct {
+	// Values holds the boundary row's value for every sort field
+	Values []interface{}
+	// Backwards selects the rows before the boundary instead of after it
+	Backwards bool
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// UserList is one page of users
+type UserList struct {
+	Users []models.User
+	// Total counts every user, ignoring paging
+	Total int64
+	// HasMore reports whether more rows exist past the page
+	HasMore bool
+}
+
+// UserRepository abstracts user persistence
+type UserRepository interface {
+	// Create inserts a new user; it returns ErrDuplicate when the email is taken
+	Create(ctx context.Context, user *models.User) error
+	// Get returns a user by ID
+	Get(ctx context.Context, id uint) (*models.User, error)
+	// Update applies the non-zero fields of changes; it returns ErrDuplicate when the email is taken
+	Update(ctx context.Context, id uint, changes *models.User) (*models.User, error)
+	// Delete soft-deletes a user
+	Delete(ctx context.Context, id uint) error
+	// List returns a page of users ordered by ID
+	List(ctx context.Context, page Page) (*UserList, error)
+}
+
+// PostListOptions controls filtering and paging of post lists
+type PostListOptions struct {
+	// UserID limits the list to one user's posts when non-zero
+	UserID uint
+	// IncludeUser loads each post's author
+	IncludeUser bool
+	Page        Page
+}
+
+// PostList is one page of posts
+type PostList struct {
+	Posts []models.Post
+	// Total counts every post matching the filter, ignoring paging
+	Total int64
+	// HasMore reports whether more rows exist past the page
+	HasMore bool
+}
+
+// PostRepository abstracts post persistence
+type PostRepository interface {
+	// Create inserts a new post; it returns ErrNotFound when the author does not exist
+	Create(ctx context.Context, post *models.Post) error
+	// Get returns a post by ID, optionally with its author
+	Get(ctx context.Context, id uint, includeUser bool) (*models.Post, error)
+	// Update applies the non-zero title and content of changes
+	Update(ctx context.Context, id uint, changes *models.Post) (*models.Post, error)
+	// Delete soft-deletes a post
+	Delete(ctx context.Context, id uint) error
+	// List returns a page of posts ordered by ID; it returns ErrNotFound when
+	// filtering by a user that does not exist
+	List(ctx context.Context, opts PostListOptions) (*PostList, error)
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// GormUserRepository is the PostgreSQL-backed UserRepository
+type GormUserRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUserRepository creates a repository on top of a GORM connection
+func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
+	return &GormUserRepository{db: db}
+}
+
+// Create inserts a new user
+func (r *GormUserRepository) Create(ctx context.Context, user *models.User) error {
+	return translateWriteError(r.db.WithContext(ctx).Create(user).Error)
+}
+
+// Get returns a user by ID
+func (r *GormUserRepository) Get(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Update applies the non-zero fields of changes to an existing user
+func (r *GormUserRepository) Update(ctx context.Context, id uint, changes *models.User) (*models.User, error) {
+	db := r.db.WithContext(ctx)
+
+	var user models.User
+	if err := db.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&user).Updates(changes).Error; err != nil {
+		return nil, translateWriteError(err)
+	}
+	return r.Get(ctx, id)
+}
+
+// Delete soft-deletes a user
+func (r *GormUserRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.User{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns a page of users ordered by ID
+func (r *GormUserRepository) List(ctx context.Context, page Page) (*UserList, error) {
+	db := r.db.WithContext(ctx)
+
+	var total int64
+	if err := db.Model(&models.User{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra row to find out whether another page exists
+	var users []models.User
+	if err := db.Order("id").Offset(page.Offset).Limit(page.Limit + 1).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	list := &UserList{Total: total}
+	if len(users) > page.Limit {
+		users = users[:page.Limit]
+		list.HasMore = true
+	}
+	list.Users = users
+	return list, nil
+}
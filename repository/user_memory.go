@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourname/employee-api/models"
+)
+
+// MemoryUserRepository is an in-memory UserRepository for tests and local development
+type MemoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]models.User
+	nextID uint
+	now    func() time.Time
+}
+
+// NewMemoryUserRepository creates an empty in-memory repository
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users:  make(map[uint]models.User),
+		nextID: 1,
+		now:    time.Now,
+	}
+}
+
+// emailTaken reports whether another user, deleted or not, has the email; callers hold the lock
+func (r *MemoryUserRepository) emailTaken(email string, except uint) bool {
+	for id, user := range r.users {
+		if id != except && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// Create inserts a new user
+func (r *MemoryUserRepository) Create(_ context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.emailTaken(user.Email, 0) {
+		return ErrDuplicate
+	}
+
+	now := r.now()
+	user.ID = r.nextID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	r.nextID++
+
+	r.users[user.ID] = *user
+	return nil
+}
+
+// Get returns a user by ID
+func (r *MemoryUserRepository) Get(_ context.Context, id uint) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+// Update applies the non-zero fields of changes to an existing user
+func (r *MemoryUserRepository) Update(_ context.Context, id uint, changes *models.User) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if changes.Email != "" && r.emailTaken(changes.Email, id) {
+		return nil, ErrDuplicate
+	}
+
+	// Mirror GORM's Updates(struct), which skips zero values
+	if changes.Name != "" {
+		user.Name = changes.Name
+	}
+	if changes.Email != "" {
+		user.Email = changes.Email
+	}
+	user.UpdatedAt = r.now()
+
+	r.users[id] = user
+	return &user, nil
+}
+
+// Delete soft-deletes a user
+func (r *MemoryUserRepository) Delete(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return ErrNotFound
+	}
+	user.DeletedAt = gorm.DeletedAt{Time: r.now(), Valid: true}
+	r.users[id] = user
+	return nil
+}
+
+// List returns a page of users ordered by ID
+func (r *MemoryUserRepository) List(_ context.Context, page Page) (*UserList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]models.User, 0, len(r.users))
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	list := &UserList{Total: int64(len(users))}
+	list.Users, list.HasMore = pageOf(users, page)
+	return list, nil
+}
+
+// pageOf returns the window of rows selected by page and whether more rows follow it
+func pageOf[T any](rows []T, page Page) ([]T, bool) {
+	if page.Offset >= len(rows) {
+		return []T{}, false
+	}
+	rows = rows[page.Offset:]
+	if len(rows) > page.Limit {
+		return rows[:page.Limit], true
+	}
+	return rows, false
+}
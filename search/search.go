@@ -0,0 +1,134 @@
+// Package search splits search queries into terms and implements the trigram
+// similarity and highlighting employee search uses. The functions mirror the
+// PostgreSQL text search and pg_trgm behavior the database implementation
+// relies on, so that the in-memory implementation finds the same employees.
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// MaxTerms bounds the words of a query that are searched for
+const MaxTerms = 10
+
+// WordSimilarityThreshold is the word similarity from which a name matches a
+// query, the default of pg_trgm.word_similarity_threshold
+const WordSimilarityThreshold = 0.6
+
+// Highlight markers wrapped around matching words
+const (
+	MarkStart = "<mark>"
+	MarkEnd   = "</mark>"
+)
+
+// isWordRune reports whether a rune belongs to a word; everything else
+// separates words, like the PostgreSQL "simple" text search parser does
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Terms returns the lower-cased words of a query, at most MaxTerms
+func Terms(query string) []string {
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool { return !isWordRune(r) })
+	if len(terms) > MaxTerms {
+		terms = terms[:MaxTerms]
+	}
+	return terms
+}
+
+// PrefixQuery returns a tsquery matching text that has words starting with
+// every term, e.g. "ada:* & love:*". Terms contain only letters and digits,
+// so they need no quoting.
+func PrefixQuery(terms []string) string {
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+	return strings.Join(prefixes, " & ")
+}
+
+// HasPrefixes reports whether every term starts one of the words of text
+func HasPrefixes(text string, terms []string) bool {
+	words := Terms(text)
+	for _, term := range terms {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Trigrams returns the set of trigrams of the words of text the way pg_trgm
+// builds it: each lower-cased word is padded with two spaces in front and
+// one behind
+func Trigrams(text string) map[string]struct{} {
+	trigrams := make(map[string]struct{})
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !isWordRune(r) }) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			trigrams[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return trigrams
+}
+
+// WordSimilarity returns the share of the trigrams of a that also occur in
+// b, from 0 to 1. It approximates pg_trgm's word_similarity(a, b): a name
+// scores high against a query that contains it, even misspelled.
+func WordSimilarity(a, b string) float64 {
+	ta := Trigrams(a)
+	if len(ta) == 0 {
+		return 0
+	}
+	tb := Trigrams(b)
+	shared := 0
+	for trigram := range ta {
+		if _, ok := tb[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta))
+}
+
+// Matches reports whether a word matches one of the terms, by prefix or by
+// being similar to it
+func Matches(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) || WordSimilarity(word, term) >= WordSimilarityThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlight HTML-escapes text and wraps the words matching any of the terms
+// in MarkStart and MarkEnd
+func Highlight(text string, terms []string) string {
+	var out strings.Builder
+	runes := []rune(text)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		word := isWordRune(runes[start])
+		for end < len(runes) && isWordRune(runes[end]) == word {
+			end++
+		}
+		segment := string(runes[start:end])
+		if word && Matches(segment, terms) {
+			out.WriteString(MarkStart + html.EscapeString(segment) + MarkEnd)
+		} else {
+			out.WriteString(html.EscapeString(segment))
+		}
+		start = end
+	}
+	return out.String()
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"o", "brien", "anne", "marie"}, Terms("  O'Brien, Anne-Marie "))
+	assert.Empty(t, Terms(" -- "))
+	assert.Len(t, Terms(strings.Repeat("a ", 20)), MaxTerms)
+	assert.Equal(t, "ada:* & love:*", PrefixQuery([]string{"ada", "love"}))
+}
+
+func TestHasPrefixes(t *testing.T) {
+	assert.True(t, HasPrefixes("Ada Lovelace", []string{"love", "ad"}))
+	assert.False(t, HasPrefixes("Ada Lovelace", []string{"ada", "turing"}))
+	assert.False(t, HasPrefixes("Ada Lovelace", []string{"velace"}))
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		// Example from the pg_trgm documentation
+		{"word", "two words", 0.8},
+		{"Lovelace", "ada lovelase", 6.0 / 9.0},
+		{"Ada", "ada lovelace", 1},
+		{"Turing", "ada lovelace", 0},
+		{"", "ada", 0},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, WordSimilarity(tt.a, tt.b), 0.001, "%s ~ %s", tt.a, tt.b)
+	}
+	assert.GreaterOrEqual(t, WordSimilarity("Lovelace", "lovelase"), WordSimilarityThreshold)
+	assert.Less(t, WordSimilarity("Hopper", "lovelase"), WordSimilarityThreshold)
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		text  string
+		terms []string
+		want  string
+	}{
+		{"Ada Lovelace", []string{"love"}, "Ada <mark>Lovelace</mark>"},
+		{"Ada Lovelace", []string{"lovelase", "ad"}, "<mark>Ada</mark> <mark>Lovelace</mark>"},
+		{"O'Brien", []string{"brie"}, "O&#39;<mark>Brien</mark>"},
+		{"Grace Hopper", []string{"turing"}, "Grace Hopper"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Highlight(tt.text, tt.terms), tt.text)
+	}
+}
@@ -0,0 +1,111 @@
+// Package spreadsheet reads the rows of CSV files and of the first worksheet
+// of XLSX workbooks as strings.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format is a spreadsheet file format
+type Format string
+
+// Supported formats
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// Media types of the supported formats
+const (
+	CSVContentType  = "text/csv"
+	XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported spreadsheet format")
+
+// ErrInvalidFile is returned for files that cannot be parsed in their format
+var ErrInvalidFile = errors.New("invalid spreadsheet")
+
+// ErrTooManyRows is returned for files with more rows than the caller accepts
+var ErrTooManyRows = errors.New("too many rows")
+
+// zipMagic starts every XLSX file, which is a zip archive
+var zipMagic = []byte("PK\x03\x04")
+
+// utf8BOM is written at the start of CSV files by some spreadsheet programs
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// DetectFormat tells the format of a file from its media type, its file name
+// or, failing both, its content
+func DetectFormat(filename, contentType string, data []byte) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0])) {
+	case CSVContentType, "application/csv":
+		return CSV, nil
+	case XLSXContentType:
+		return XLSX, nil
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return CSV, nil
+	case ".xlsx":
+		return XLSX, nil
+	case ".xls", ".ods", ".numbers":
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Ext(filename))
+	}
+	if bytes.HasPrefix(data, zipMagic) {
+		return XLSX, nil
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", ErrUnsupportedFormat
+	}
+	return CSV, nil
+}
+
+// Read returns the rows of a file, the header row included. Rows are not
+// padded, so they may differ in length. Reading more than maxRows rows fails
+// with ErrTooManyRows.
+func Read(data []byte, format Format, maxRows int) ([][]string, error) {
+	switch format {
+	case CSV:
+		return readCSV(data, maxRows)
+	case XLSX:
+		return readXLSX(data, maxRows)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// readCSV reads comma or semicolon separated values, the delimiter being
+// whichever the header line uses more of
+func readCSV(data []byte, maxRows int) ([][]string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		if len(rows) == maxRows {
+			return nil, ErrTooManyRows
+		}
+		rows = append(rows, record)
+	}
+}
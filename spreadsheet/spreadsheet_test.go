@@ -0,0 +1,161 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildXLSX zips workbook parts into an XLSX file
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+const testWorkbook = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+	<sheets><sheet name="Roster" sheetId="1" r:id="rId3"/><sheet name="Other" sheetId="2" r:id="rId4"/></sheets>
+</workbook>`
+
+const testRelationships = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId4" Type="worksheet" Target="worksheets/other.xml"/>
+	<Relationship Id="rId3" Type="worksheet" Target="worksheets/roster.xml"/>
+</Relationships>`
+
+const testSharedStrings = `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="4" uniqueCount="4">
+	<si><t>employee_number</t></si>
+	<si><t>First Name</t></si>
+	<si><t>Last Name</t></si>
+	<si><r><t>Lo</t></r><r><rPr><b/></rPr><t>velace</t></r></si>
+</sst>`
+
+const testSheet = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<sheetData>
+		<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+		<row r="2"><c r="A2"><v>1001</v></c><c r="B2" t="inlineStr"><is><t>Ada</t></is></c><c r="C2" t="s"><v>3</v></c></row>
+		<row r="4"><c r="B4" t="str"><v>Alan</v></c><c r="D4" t="b"><v>1</v></c></row>
+		<row r="5"></row>
+	</sheetData>
+</worksheet>`
+
+func TestRead_XLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRelationships,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/worksheets/roster.xml":   testSheet,
+		"xl/worksheets/other.xml":    `<worksheet><sheetData><row r="1"><c r="A1"><v>1</v></c></row></sheetData></worksheet>`,
+	})
+
+	rows, err := Read(data, XLSX, 10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"employee_number", "First Name", "Last Name"},
+		{"1001", "Ada", "Lovelace"},
+		nil,
+		{"", "Alan", "", "TRUE"},
+	}, rows)
+
+	_, err = Read(data, XLSX, 3)
+	assert.ErrorIs(t, err, ErrTooManyRows)
+}
+
+func TestRead_XLSXWithoutWorkbook(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row><c><v>1.50</v></c><c t="e"><v>#N/A</v></c></row></sheetData></worksheet>`,
+	})
+
+	rows, err := Read(data, XLSX, 10)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"1.5", "#N/A"}}, rows)
+}
+
+func TestRead_InvalidXLSX(t *testing.T) {
+	tests := map[string][]byte{
+		"not a zip":      []byte("PK\x03\x04garbage"),
+		"no worksheet":   buildXLSX(t, map[string]string{"xl/workbook.xml": testWorkbook}),
+		"missing string": buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row><c t="s"><v>3</v></c></row></sheetData></worksheet>`}),
+		"bad reference":  buildXLSX(t, map[string]string{"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row><c r="12"><v>3</v></c></row></sheetData></worksheet>`}),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Read(data, XLSX, 10)
+			assert.ErrorIs(t, err, ErrInvalidFile)
+		})
+	}
+}
+
+func TestRead_CSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		rows [][]string
+	}{
+		{
+			name: "commas",
+			data: "first_name,last_name\nAda,Lovelace\n\"Turing, Alan\",\n",
+			rows: [][]string{{"first_name", "last_name"}, {"Ada", "Lovelace"}, {"Turing, Alan", ""}},
+		},
+		{
+			name: "semicolons and byte order mark",
+			data: "\xef\xbb\xbffirst_name;last_name\r\nAda;Lovelace;extra\r\n",
+			rows: [][]string{{"first_name", "last_name"}, {"Ada", "Lovelace", "extra"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Read([]byte(tt.data), CSV, 10)
+			require.NoError(t, err)
+			assert.Equal(t, tt.rows, rows)
+		})
+	}
+
+	_, err := Read([]byte("a\nb\nc\n"), CSV, 2)
+	assert.ErrorIs(t, err, ErrTooManyRows)
+	_, err = Read([]byte("a,\"b\n"), CSV, 2)
+	assert.ErrorIs(t, err, ErrInvalidFile)
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name, filename, contentType, data string
+		format                            Format
+		err                               error
+	}{
+		{name: "csv media type", contentType: "text/csv; charset=utf-8", data: "PK\x03\x04", format: CSV},
+		{name: "xlsx media type", contentType: XLSXContentType, format: XLSX},
+		{name: "csv extension", filename: "roster.CSV", contentType: "application/octet-stream", format: CSV},
+		{name: "xlsx extension", filename: "roster.xlsx", format: XLSX},
+		{name: "legacy excel", filename: "roster.xls", err: ErrUnsupportedFormat},
+		{name: "zip content", contentType: "application/octet-stream", data: "PK\x03\x04", format: XLSX},
+		{name: "binary content", data: "\x00\x01", err: ErrUnsupportedFormat},
+		{name: "text content", data: "first_name,last_name", format: CSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := DetectFormat(tt.filename, tt.contentType, []byte(tt.data))
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, format)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxXMLSize bounds how much of one workbook part is decompressed, so a small
+// archive cannot expand into an unbounded amount of memory
+const maxXMLSize = 64 << 20
+
+// maxColumns is the number of columns of an Excel worksheet, XFD being the last
+const maxColumns = 16384
+
+// defaultSheet is where the first worksheet lives in workbooks written by Excel
+const defaultSheet = "xl/worksheets/sheet1.xml"
+
+// xlsxWorkbook lists the worksheets of a workbook in tab order
+type xlsxWorkbook struct {
+	Sheets []struct {
+		ID string `xml:"id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships maps relationship IDs to the workbook parts they name
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxSharedStrings holds the strings cells refer to by index
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText is plain text or formatted runs of text
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var text strings.Builder
+	text.WriteString(t.Text)
+	for _, run := range t.Runs {
+		text.WriteString(run.Text)
+	}
+	return text.String()
+}
+
+// xlsxWorksheet holds the rows of a worksheet; empty rows and cells may be left out
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX reads the first worksheet of a workbook. Cells hold the text of
+// strings, numbers as written by Excel and booleans as TRUE or FALSE;
+// formulas are not evaluated, their cached value is used.
+func readXLSX(data []byte, maxRows int) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var shared xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXML(file, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	file, ok := files[firstSheet(files)]
+	if !ok {
+		return nil, fmt.Errorf("%w: workbook has no worksheet", ErrInvalidFile)
+	}
+	var sheet xlsxWorksheet
+	if err := decodeXML(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		index := len(rows)
+		if row.Number > 0 {
+			index = row.Number - 1
+		}
+		if index < len(rows) {
+			return nil, fmt.Errorf("%w: row %d is out of order", ErrInvalidFile, row.Number)
+		}
+
+		var cells []string
+		for _, cell := range row.Cells {
+			column := len(cells)
+			if cell.Ref != "" {
+				if column, err = columnIndex(cell.Ref); err != nil {
+					return nil, err
+				}
+			}
+			if column < len(cells) {
+				return nil, fmt.Errorf("%w: cell %s is out of order", ErrInvalidFile, cell.Ref)
+			}
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				i, err := strconv.Atoi(value)
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, fmt.Errorf("%w: cell %s refers to a missing string", ErrInvalidFile, cell.Ref)
+				}
+				value = shared.Items[i].String()
+			case "inlineStr":
+				value = cell.Inline.String()
+			case "b":
+				value = strings.ToUpper(strconv.FormatBool(value == "1"))
+			case "", "n":
+				if number, err := strconv.ParseFloat(value, 64); err == nil {
+					value = strconv.FormatFloat(number, 'f', -1, 64)
+				}
+			}
+			for len(cells) < column {
+				cells = append(cells, "")
+			}
+			cells = append(cells, value)
+		}
+		if len(cells) == 0 {
+			continue
+		}
+
+		if index >= maxRows {
+			return nil, ErrTooManyRows
+		}
+		for len(rows) < index {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// firstSheet returns the name of the part holding the first worksheet
+func firstSheet(files map[string]*zip.File) string {
+	var workbook xlsxWorkbook
+	var relationships xlsxRelationships
+	workbookFile, ok := files["xl/workbook.xml"]
+	relsFile, relsOK := files["xl/_rels/workbook.xml.rels"]
+	if !ok || !relsOK || decodeXML(workbookFile, &workbook) != nil ||
+		decodeXML(relsFile, &relationships) != nil || len(workbook.Sheets) == 0 {
+		return defaultSheet
+	}
+
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(relationship.Target, "/") {
+			return strings.TrimPrefix(relationship.Target, "/")
+		}
+		return path.Join("xl", relationship.Target)
+	}
+	return defaultSheet
+}
+
+// decodeXML decompresses and decodes one part of a workbook
+func decodeXML(file *zip.File, value interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxXMLSize+1))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if len(data) > maxXMLSize {
+		return fmt.Errorf("%w: %s is too large", ErrInvalidFile, file.Name)
+	}
+	if err := xml.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidFile, file.Name, err)
+	}
+	return nil
+}
+
+// columnIndex returns the zero-based column of a cell reference like "AB12"
+func columnIndex(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A') + 1
+		letters++
+		if column > maxColumns {
+			break
+		}
+	}
+	if letters == 0 || column > maxColumns {
+		return 0, fmt.Errorf("%w: invalid cell reference %q", ErrInvalidFile, ref)
+	}
+	return column - 1, nil
+}
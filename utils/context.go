@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestIDKey is the context key under which the request ID is stored
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or an empty string.
+// Both a *gin.Context and a request context derived from it are supported.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		if requestID, exists := ginCtx.Get("request_id"); exists {
+			if id, ok := requestID.(string); ok {
+				return id
+			}
+		}
+		if ginCtx.Request == nil {
+			return ""
+		}
+		ctx = ginCtx.Request.Context()
+	}
+	if requestID, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return requestID
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// StatusClientClosedRequest is the non-standard status for requests the client
+// abandoned before a response was ready
+const StatusClientClosedRequest = 499
+
+// Machine-readable codes of database errors
+const (
+	ErrCodeNotFound            = "not_found"
+	ErrCodeDuplicate           = "duplicate"
+	ErrCodeForeignKeyViolation = "foreign_key_violation"
+	ErrCodeConstraintViolation = "constraint_violation"
+	ErrCodeRetryable           = "retryable"
+	ErrCodeUnavailable         = "database_unavailable"
+	ErrCodeTimeout             = "timeout"
+	ErrCodeCanceled            = "canceled"
+	ErrCodeInternal            = "internal_error"
+)
+
+// PostgreSQL error codes (SQLSTATE) the translation inspects
+const (
+	sqlStateUniqueViolation      = "23505"
+	sqlStateForeignKeyViolation  = "23503"
+	sqlStateCheckViolation       = "23514"
+	sqlStateNotNullViolation     = "23502"
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+	sqlStateQueryCanceled        = "57014"
+	sqlStateClassConnection      = "08"
+)
+
+// DBError describes how a database error is reported to clients
+type DBError struct {
+	// Status is the HTTP status
+	Status int
+	// Code is the machine-readable error code
+	Code string
+	// Message is a client-safe description; empty for internal errors, whose
+	// wording depends on the operation
+	Message string
+	// Retryable reports whether repeating the request may succeed
+	Retryable bool
+	// SQLState and Constraint are set for PostgreSQL errors
+	SQLState   string
+	Constraint string
+}
+
+// TranslateDBError classifies an error returned by GORM or the pgx driver
+func TranslateDBError(err error) DBError {
+	var pgErr *pgconn.PgError
+	translated := DBError{Status: http.StatusInternalServerError, Code: ErrCodeInternal}
+	if errors.As(err, &pgErr) {
+		translated.SQLState = pgErr.Code
+		translated.Constraint = pgErr.ConstraintName
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		translated.Status, translated.Code = http.StatusNotFound, ErrCodeNotFound
+		translated.Message = "Resource not found"
+
+	// A canceled request context surfaces as query_canceled too, so check it first
+	case errors.Is(err, context.Canceled):
+		translated.Status, translated.Code = StatusClientClosedRequest, ErrCodeCanceled
+		translated.Message = "Request was canceled"
+	case errors.Is(err, context.DeadlineExceeded), translated.SQLState == sqlStateQueryCanceled:
+		translated.Status, translated.Code = http.StatusGatewayTimeout, ErrCodeTimeout
+		translated.Message = "Database query timed out"
+
+	case errors.Is(err, gorm.ErrDuplicatedKey), translated.SQLState == sqlStateUniqueViolation:
+		translated.Status, translated.Code = http.StatusConflict, ErrCodeDuplicate
+		translated.Message = "Resource already exists"
+	case errors.Is(err, gorm.ErrForeignKeyViolated), translated.SQLState == sqlStateForeignKeyViolation:
+		translated.Status, translated.Code = http.StatusUnprocessableEntity, ErrCodeForeignKeyViolation
+		translated.Message = "A referenced resource does not exist or is still referenced"
+	case translated.SQLState == sqlStateCheckViolation, translated.SQLState == sqlStateNotNullViolation:
+		translated.Status, translated.Code = http.StatusBadRequest, ErrCodeConstraintViolation
+		translated.Message = "Request violates a data constraint"
+
+	case translated.SQLState == sqlStateSerializationFailure, translated.SQLState == sqlStateDeadlockDetected:
+		translated.Status, translated.Code = http.StatusServiceUnavailable, ErrCodeRetryable
+		translated.Message = "Conflicting concurrent update, retry the request"
+		translated.Retryable = true
+	case strings.HasPrefix(translated.SQLState, sqlStateClassConnection):
+		translated.Status, translated.Code = http.StatusServiceUnavailable, ErrCodeUnavailable
+		translated.Message = "Database is unavailable, retry the request"
+		translated.Retryable = true
+	}
+
+	return translated
+}
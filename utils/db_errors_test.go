@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateDBError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		code      string
+		retryable bool
+	}{
+		{name: "not found", err: gorm.ErrRecordNotFound, status: http.StatusNotFound, code: ErrCodeNotFound},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"}, status: http.StatusConflict, code: ErrCodeDuplicate},
+		{name: "translated duplicate", err: fmt.Errorf("%w: %w", gorm.ErrDuplicatedKey, errors.New("driver")), status: http.StatusConflict, code: ErrCodeDuplicate},
+		{name: "foreign key violation", err: &pgconn.PgError{Code: "23503"}, status: http.StatusUnprocessableEntity, code: ErrCodeForeignKeyViolation},
+		{name: "check violation", err: &pgconn.PgError{Code: "23514"}, status: http.StatusBadRequest, code: ErrCodeConstraintViolation},
+		{name: "not null violation", err: &pgconn.PgError{Code: "23502"}, status: http.StatusBadRequest, code: ErrCodeConstraintViolation},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, status: http.StatusServiceUnavailable, code: ErrCodeRetryable, retryable: true},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, status: http.StatusServiceUnavailable, code: ErrCodeRetryable, retryable: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, status: http.StatusServiceUnavailable, code: ErrCodeUnavailable, retryable: true},
+		{name: "statement timeout", err: &pgconn.PgError{Code: "57014"}, status: http.StatusGatewayTimeout, code: ErrCodeTimeout},
+		{name: "deadline exceeded", err: fmt.Errorf("query: %w", context.DeadlineExceeded), status: http.StatusGatewayTimeout, code: ErrCodeTimeout},
+		{name: "canceled", err: fmt.Errorf("query: %w", context.Canceled), status: StatusClientClosedRequest, code: ErrCodeCanceled},
+		{name: "other", err: errors.New("boom"), status: http.StatusInternalServerError, code: ErrCodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translated := TranslateDBError(tt.err)
+			assert.Equal(t, tt.status, translated.Status)
+			assert.Equal(t, tt.code, translated.Code)
+			assert.Equal(t, tt.retryable, translated.Retryable)
+		})
+	}
+}
+
+func TestTranslateDBError_WrappedPgError(t *testing.T) {
+	err := fmt.Errorf("create user: %w", &pgconn.PgError{Code: "23505", ConstraintName: "idx_users_email"})
+
+	translated := TranslateDBError(err)
+	assert.Equal(t, http.StatusConflict, translated.Status)
+	assert.Equal(t, "23505", translated.SQLState)
+	assert.Equal(t, "idx_users_email", translated.Constraint)
+}
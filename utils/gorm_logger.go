@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	gormutils "gorm.io/gorm/utils"
+)
+
+// GormLoggerConfig configures the GORM logger bridge
+type GormLoggerConfig struct {
+	LogLevel                  logger.LogLevel
+	SlowThreshold             time.Duration
+	IgnoreRecordNotFoundError bool
+}
+
+// GormLogger implements gorm's logger.Interface on top of logrus so SQL
+// statements share the application's JSON log stream and request IDs
+type GormLogger struct {
+	logger *logrus.Logger
+	// config is shared with copies made by LogMode so SetConfig reaches them
+	config *atomic.Pointer[GormLoggerConfig]
+	// level overrides the configured level on copies made by LogMode
+	level *logger.LogLevel
+}
+
+// NewGormLogger creates a GORM logger writing to the given logrus logger
+func NewGormLogger(log *logrus.Logger, config GormLoggerConfig) *GormLogger {
+	l := &GormLogger{logger: log, config: &atomic.Pointer[GormLoggerConfig]{}}
+	l.SetConfig(config)
+	return l
+}
+
+// SetConfig atomically replaces the logger settings, e.g. on config reload
+func (l *GormLogger) SetConfig(config GormLoggerConfig) {
+	l.config.Store(&config)
+}
+
+// LogMode returns a copy of the logger with the given level
+func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *l
+	clone.level = &level
+	return &clone
+}
+
+// settings returns the current settings, applying any LogMode override
+func (l *GormLogger) settings() GormLoggerConfig {
+	config := *l.config.Load()
+	if l.level != nil {
+		config.LogLevel = *l.level
+	}
+	return config
+}
+
+// Info logs an informational message from GORM
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.settings().LogLevel >= logger.Info {
+		l.entry(ctx).Info(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn logs a warning from GORM
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.settings().LogLevel >= logger.Warn {
+		l.entry(ctx).Warn(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error logs an error from GORM
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.settings().LogLevel >= logger.Error {
+		l.entry(ctx).Error(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace logs an executed SQL statement with its duration and affected rows
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	config := l.settings()
+	if config.LogLevel <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	slow := config.SlowThreshold > 0 && elapsed > config.SlowThreshold
+	failed := err != nil && !(config.IgnoreRecordNotFoundError && errors.Is(err, gorm.ErrRecordNotFound))
+
+	switch {
+	case failed && config.LogLevel >= logger.Error:
+	case slow && config.LogLevel >= logger.Warn:
+	case config.LogLevel >= logger.Info:
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	fields := logrus.Fields{
+		"type":        "sql",
+		"sql":         sql,
+		"duration_ms": float64(elapsed.Nanoseconds()) / 1e6,
+		"slow_query":  slow,
+		"source":      gormutils.FileWithLineNum(),
+	}
+	if rows >= 0 {
+		fields["rows_affected"] = rows
+	}
+
+	entry := l.entry(ctx).WithFields(fields)
+	switch {
+	case failed:
+		entry.WithError(err).Error("SQL query failed")
+	case slow:
+		entry.WithField("slow_threshold_ms", config.SlowThreshold.Milliseconds()).Warn("Slow SQL query")
+	default:
+		entry.Info("SQL query")
+	}
+}
+
+// entry returns a log entry tagged with the request ID carried by ctx
+func (l *GormLogger) entry(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(l.logger)
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		entry = entry.WithField("request_id", requestID)
+	}
+	return entry
+}
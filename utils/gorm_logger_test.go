@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestGormLogger returns a GORM logger writing JSON lines into a buffer
+func newTestGormLogger(level logger.LogLevel) (*GormLogger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	log := logrus.New()
+	log.SetOutput(buf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+
+	return NewGormLogger(log, GormLoggerConfig{
+		LogLevel:                  level,
+		SlowThreshold:             100 * time.Millisecond,
+		IgnoreRecordNotFoundError: true,
+	}), buf
+}
+
+// lastEntry decodes the most recent JSON log line
+func lastEntry(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	entry := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(lines[len(lines)-1], &entry))
+	return entry
+}
+
+func TestGormLogger_Trace(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	query := func() (string, int64) { return `SELECT * FROM "employees"`, 3 }
+
+	t.Run("logs query with request ID", func(t *testing.T) {
+		l, buf := newTestGormLogger(logger.Info)
+		l.Trace(ctx, time.Now(), query, nil)
+
+		entry := lastEntry(t, buf)
+		assert.Equal(t, "info", entry["level"])
+		assert.Equal(t, "req-123", entry["request_id"])
+		assert.Equal(t, `SELECT * FROM "employees"`, entry["sql"])
+		assert.Equal(t, float64(3), entry["rows_affected"])
+		assert.Equal(t, false, entry["slow_query"])
+		assert.Contains(t, entry, "duration_ms")
+	})
+
+	t.Run("flags slow queries", func(t *testing.T) {
+		l, buf := newTestGormLogger(logger.Warn)
+		l.Trace(ctx, time.Now().Add(-time.Second), query, nil)
+
+		entry := lastEntry(t, buf)
+		assert.Equal(t, "warning", entry["level"])
+		assert.Equal(t, true, entry["slow_query"])
+	})
+
+	t.Run("logs errors", func(t *testing.T) {
+		l, buf := newTestGormLogger(logger.Error)
+		l.Trace(ctx, time.Now(), query, errors.New("connection reset"))
+
+		entry := lastEntry(t, buf)
+		assert.Equal(t, "error", entry["level"])
+		assert.Equal(t, "connection reset", entry["error"])
+	})
+
+	t.Run("skips fast queries and not found below info", func(t *testing.T) {
+		l, buf := newTestGormLogger(logger.Warn)
+		l.Trace(ctx, time.Now(), query, nil)
+		l.Trace(ctx, time.Now(), query, gorm.ErrRecordNotFound)
+
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("silent logs nothing", func(t *testing.T) {
+		l, buf := newTestGormLogger(logger.Info)
+		l.LogMode(logger.Silent).Trace(ctx, time.Now(), query, errors.New("boom"))
+
+		assert.Zero(t, buf.Len())
+	})
+}
@@ -2,11 +2,11 @@ package utils
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
 )
 
 var AppLogger *logrus.Logger
@@ -55,10 +55,22 @@ func LogDBError(ctx context.Context, operation string, err error, additionalFiel
 		}
 	}
 
-	// Check if it's a specific GORM error
-	if err == gorm.ErrRecordNotFound {
+	// Classify the error; only failures the client did not cause are logged as errors
+	translated := TranslateDBError(err)
+	fields["error_code"] = translated.Code
+	if translated.SQLState != "" {
+		fields["sqlstate"] = translated.SQLState
+	}
+	if translated.Constraint != "" {
+		fields["constraint"] = translated.Constraint
+	}
+
+	switch {
+	case translated.Code == ErrCodeNotFound:
 		logger.WithFields(fields).Warn("Database record not found")
-	} else {
+	case translated.Status < http.StatusInternalServerError:
+		logger.WithFields(fields).Warn("Database operation rejected")
+	default:
 		logger.WithFields(fields).Error("Database operation failed")
 	}
 }
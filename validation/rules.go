@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// rule is a custom validation tag with the reason reported when it fails
+type rule struct {
+	check  validator.Func
+	reason string
+}
+
+// rules are the custom tags; "email" replaces the validator's built-in rule
+var rules = map[string]rule{
+	"name": {
+		check:  isName,
+		reason: "must contain only letters, spaces, hyphens, apostrophes and periods",
+	},
+	"email": {
+		check:  isEmail,
+		reason: "must be a valid email address",
+	},
+	"future": {
+		check:  isFuture,
+		reason: "must be in the future",
+	},
+	"scope": {
+		check:  isScope,
+		reason: "must be a non-empty scope without whitespace",
+	},
+	"employee_number": {
+		check:  isEmployeeNumber,
+		reason: "must contain only letters, digits, hyphens, underscores, periods and slashes",
+	},
+}
+
+// isName accepts personal names such as "Anne-Marie", "O'Brien" or "J. R.",
+// in any script, without leading or trailing spaces
+func isName(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if strings.TrimSpace(name) != name {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r), unicode.Is(unicode.Mn, r):
+		case r == ' ', r == '-', r == '\'', r == '’', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isEmail accepts a bare address; display names and other forms that
+// mail.ParseAddress understands are rejected
+func isEmail(fl validator.FieldLevel) bool {
+	email := fl.Field().String()
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
+
+// isFuture accepts times after now
+func isFuture(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && t.After(time.Now())
+}
+
+// isScope accepts an OAuth scope token
+func isScope(fl validator.FieldLevel) bool {
+	scope := fl.Field().String()
+	return scope != "" && !strings.ContainsAny(scope, " \t\r\n")
+}
+
+// isEmployeeNumber accepts ASCII identifiers such as "E-1042" or "HR/2024/17"
+func isEmployeeNumber(fl validator.FieldLevel) bool {
+	for _, r := range fl.Field().String() {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == '/':
+		default:
+			return false
+		}
+	}
+	return true
+}
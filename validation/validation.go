@@ -0,0 +1,163 @@
+// Package validation checks request bodies against their validate struct
+// tags using go-playground/validator, with rules specific to this API.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes one invalid field, named as in JSON
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Reason
+}
+
+// Errors lists every invalid field of a value, in declaration order
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validate is safe for concurrent use and caches struct metadata
+var validate = newValidator()
+
+// newValidator returns a validator that names fields by their JSON name and
+// knows the custom rules
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	for tag, rule := range rules {
+		if err := v.RegisterValidation(tag, rule.check); err != nil {
+			panic(fmt.Sprintf("validation: registering %q: %v", tag, err))
+		}
+	}
+	return v
+}
+
+// Struct checks a struct, or a pointer to one, against its validate tags. It
+// returns Errors naming every failing field, or nil.
+func Struct(value interface{}) error {
+	return translate(validate.Struct(value), false)
+}
+
+// Partial is like Struct for bodies that may leave fields empty to keep their
+// current values, as sent to update: a missing required field is not an error.
+func Partial(value interface{}) error {
+	return translate(validate.Struct(value), true)
+}
+
+// Prefix names the fields of a nested value's errors from the enclosing
+// value, e.g. "first_name" becomes "operations[2].employee.first_name". Other
+// errors are returned unchanged.
+func Prefix(prefix string, err error) error {
+	var fieldErrs Errors
+	var fieldErr FieldError
+	switch {
+	case errors.As(err, &fieldErrs):
+		prefixed := make(Errors, len(fieldErrs))
+		for i, fieldErr := range fieldErrs {
+			prefixed[i] = FieldError{Field: prefix + "." + fieldErr.Field, Reason: fieldErr.Reason}
+		}
+		return prefixed
+	case errors.As(err, &fieldErr):
+		return FieldError{Field: prefix + "." + fieldErr.Field, Reason: fieldErr.Reason}
+	default:
+		return err
+	}
+}
+
+// translate converts validator errors to Errors
+func translate(err error, partial bool) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	var fieldErrs Errors
+	for _, validationErr := range validationErrs {
+		if partial && validationErr.Tag() == "required" {
+			continue
+		}
+		fieldErrs = append(fieldErrs, FieldError{
+			Field:  fieldName(validationErr),
+			Reason: reason(validationErr),
+		})
+	}
+	if len(fieldErrs) == 0 {
+		return nil
+	}
+	return fieldErrs
+}
+
+// fieldName returns the JSON path of a field without the struct name,
+// e.g. "scopes[1]" rather than "APIKeyRequest.scopes[1]"
+func fieldName(err validator.FieldError) string {
+	namespace := err.Namespace()
+	if _, path, found := strings.Cut(namespace, "."); found {
+		return path
+	}
+	return namespace
+}
+
+// reason describes a failed rule in words
+func reason(err validator.FieldError) string {
+	if rule, ok := rules[err.Tag()]; ok {
+		return rule.reason
+	}
+
+	switch err.Tag() {
+	case "required", "required_if", "required_unless":
+		return "is required"
+	case "max":
+		switch err.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be at most %s characters", err.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must have at most %s %s", err.Param(), items(err.Param()))
+		}
+		return "must be at most " + err.Param()
+	case "min":
+		switch err.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be at least %s characters", err.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must have at least %s %s", err.Param(), items(err.Param()))
+		}
+		return "must be at least " + err.Param()
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(err.Param()), ", ")
+	default:
+		return "is invalid"
+	}
+}
+
+// items returns "item" or "items" to follow a count
+func items(count string) string {
+	if count == "1" {
+		return "item"
+	}
+	return "items"
+}
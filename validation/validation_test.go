@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPerson struct {
+	FirstName string     `json:"first_name" validate:"required,max=10,name"`
+	Email     string     `json:"email" validate:"omitempty,email"`
+	Scopes    []string   `json:"scopes" validate:"max=2,dive,scope"`
+	StartsAt  *time.Time `json:"starts_at,omitempty" validate:"omitempty,future"`
+}
+
+func TestStruct(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		value  testPerson
+		errors Errors
+	}{
+		{name: "valid", value: testPerson{FirstName: "Anne-Marie", Email: "a@example.com", Scopes: []string{"employees:read"}, StartsAt: &future}},
+		{name: "accented name", value: testPerson{FirstName: "Zoë O'Neil"}},
+		{name: "missing name", value: testPerson{}, errors: Errors{{Field: "first_name", Reason: "is required"}}},
+		{name: "name too long", value: testPerson{FirstName: "Bartholomew"}, errors: Errors{{Field: "first_name", Reason: "must be at most 10 characters"}}},
+		{name: "name with digits", value: testPerson{FirstName: "R2D2"}, errors: Errors{{Field: "first_name", Reason: "must contain only letters, spaces, hyphens, apostrophes and periods"}}},
+		{name: "name with padding", value: testPerson{FirstName: " Ada"}, errors: Errors{{Field: "first_name", Reason: "must contain only letters, spaces, hyphens, apostrophes and periods"}}},
+		{name: "display name email", value: testPerson{FirstName: "Ada", Email: "Ada <a@example.com>"}, errors: Errors{{Field: "email", Reason: "must be a valid email address"}}},
+		{name: "scope with space", value: testPerson{FirstName: "Ada", Scopes: []string{"ok", "a b"}}, errors: Errors{{Field: "scopes[1]", Reason: "must be a non-empty scope without whitespace"}}},
+		{name: "too many scopes", value: testPerson{FirstName: "Ada", Scopes: []string{"a", "b", "c"}}, errors: Errors{{Field: "scopes", Reason: "must have at most 2 items"}}},
+		{name: "past date", value: testPerson{FirstName: "Ada", StartsAt: &past}, errors: Errors{{Field: "starts_at", Reason: "must be in the future"}}},
+		{
+			name:  "every failing field",
+			value: testPerson{Email: "nope", StartsAt: &past},
+			errors: Errors{
+				{Field: "first_name", Reason: "is required"},
+				{Field: "email", Reason: "must be a valid email address"},
+				{Field: "starts_at", Reason: "must be in the future"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(&tt.value)
+			if tt.errors == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs Errors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.errors, errs)
+		})
+	}
+}
+
+func TestPartial(t *testing.T) {
+	assert.NoError(t, Partial(&testPerson{}))
+
+	err := Partial(&testPerson{FirstName: "R2D2"})
+	assert.EqualError(t, err, "first_name must contain only letters, spaces, hyphens, apostrophes and periods")
+}
+
+func TestPrefix(t *testing.T) {
+	err := Prefix("people[1]", Struct(&testPerson{Email: "nope"}))
+	assert.Equal(t, Errors{
+		{Field: "people[1].first_name", Reason: "is required"},
+		{Field: "people[1].email", Reason: "must be a valid email address"},
+	}, err)
+
+	assert.Equal(t, FieldError{Field: "people[1].age", Reason: "is invalid"},
+		Prefix("people[1]", FieldError{Field: "age", Reason: "is invalid"}))
+	assert.NoError(t, Prefix("people[1]", nil))
+}
+
+func TestErrors_Error(t *testing.T) {
+	errs := Errors{{Field: "first_name", Reason: "is required"}, {Field: "last_name", Reason: "is required"}}
+	assert.EqualError(t, errs, "first_name is required; last_name is required")
+}